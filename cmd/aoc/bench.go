@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"aoc/solver"
+)
+
+const defaultBaselinePath = "benchmarks.json"
+
+// benchmark is one phase of solving one input of a day: parse, part1 or part2.
+type benchmark struct {
+	Name string
+	F    func(b *testing.B)
+}
+
+// dayBenchmarks returns benchmarks for parsing and solving each part of every
+// input file in dir, named "<input>/<phase>".
+func dayBenchmarks(day solver.Day, dir string) ([]benchmark, error) {
+	inputPaths, err := filepath.Glob(filepath.Join(dir, "input*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	var benchmarks []benchmark
+	for _, inputPath := range inputPaths {
+		data, err := os.ReadFile(inputPath)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Base(inputPath)
+		benchmarks = append(benchmarks,
+			benchmark{name + "/parse", benchParse(day, data)},
+			benchmark{name + "/part1", benchPart(day, data, 1)},
+			benchmark{name + "/part2", benchPart(day, data, 2)},
+		)
+	}
+	return benchmarks, nil
+}
+
+func benchParse(day solver.Day, data []byte) func(b *testing.B) {
+	return func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s, err := day.NewSolver(solver.Options{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := s.Parse(bytes.NewReader(data)); err != nil {
+				b.Skipf("parse failed: %v", err)
+			}
+		}
+	}
+}
+
+func benchPart(day solver.Day, data []byte, part int) func(b *testing.B) {
+	return func(b *testing.B) {
+		s, err := day.NewSolver(solver.Options{})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := s.Parse(bytes.NewReader(data)); err != nil {
+			b.Skipf("parse failed: %v", err)
+		}
+		// Check the part can be solved before timing it
+		if _, err := solver.SolvePart(s, part); err != nil {
+			b.Skipf("part %v failed: %v", part, err)
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := solver.SolvePart(s, part); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+type BenchResult struct {
+	Name        string `json:"name"`
+	NsPerOp     int64  `json:"nsPerOp"`
+	AllocsPerOp int64  `json:"allocsPerOp"`
+	BytesPerOp  int64  `json:"bytesPerOp"`
+}
+
+type BenchReport struct {
+	GoVersion string        `json:"goVersion"`
+	Time      time.Time     `json:"time"`
+	Results   []BenchResult `json:"results"`
+}
+
+func loadBenchReport(path string) (*BenchReport, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var report BenchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return &report, nil
+}
+
+func saveBenchReport(path string, report *BenchReport) error {
+	data, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// benchCommand runs every day's benchmarks and compares the results against
+// a saved baseline, failing if any got slower or allocated more than the
+// threshold allows.
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	baselinePath := flags.String("baseline", defaultBaselinePath, "file holding the baseline results")
+	save := flags.Bool("save", false, "save the results as the new baseline")
+	threshold := flags.Float64("threshold", 10, "percentage increase in time or allocations treated as a regression")
+	filter := flags.String("run", "", "only run benchmarks whose name matches this regular expression")
+	benchtime := flags.String("benchtime", "1s", "run each benchmark for this long, or this many times with the suffix x")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("invalid arguments. Expected bench [flags]")
+	}
+	filterRegexp, err := regexp.Compile(*filter)
+	if err != nil {
+		return err
+	}
+	// testing.Benchmark reads its run time from the test flags
+	testing.Init()
+	if err := flag.Set("test.benchtime", *benchtime); err != nil {
+		return fmt.Errorf("invalid benchtime %#v: %w", *benchtime, err)
+	}
+
+	baseline, err := loadBenchReport(*baselinePath)
+	if err != nil {
+		return err
+	}
+	baselineResults := make(map[string]BenchResult)
+	if baseline != nil {
+		for _, r := range baseline.Results {
+			baselineResults[r.Name] = r
+		}
+	}
+
+	report := &BenchReport{GoVersion: runtime.Version(), Time: time.Now()}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Benchmark\tns/op\tbase ns/op\tdelta\tallocs/op\tbase allocs/op\tdelta\t\t")
+	regressions := 0
+	for _, day := range solver.Days() {
+		benchmarks, err := dayBenchmarks(day, fmt.Sprintf("day%v", day.Number))
+		if err != nil {
+			return err
+		}
+		for _, bm := range benchmarks {
+			name := fmt.Sprintf("day%v/%v", day.Number, bm.Name)
+			if !filterRegexp.MatchString(name) {
+				continue
+			}
+			r := testing.Benchmark(bm.F)
+			if r.N == 0 {
+				// Skipped, e.g. not implemented
+				continue
+			}
+			result := BenchResult{Name: name, NsPerOp: r.NsPerOp(), AllocsPerOp: r.AllocsPerOp(), BytesPerOp: r.AllocedBytesPerOp()}
+			report.Results = append(report.Results, result)
+
+			base, found := baselineResults[name]
+			if !found {
+				fmt.Fprintf(w, "%v\t%v\t-\t\t%v\t-\t\t\t\n", name, result.NsPerOp, result.AllocsPerOp)
+				continue
+			}
+			timeDelta := percentChange(base.NsPerOp, result.NsPerOp)
+			allocsDelta := percentChange(base.AllocsPerOp, result.AllocsPerOp)
+			mark := ""
+			if timeDelta > *threshold || allocsDelta > *threshold {
+				mark = "REGRESSION"
+				regressions++
+			}
+			fmt.Fprintf(w, "%v\t%v\t%v\t%+.1f%%\t%v\t%v\t%+.1f%%\t%v\t\n", name, result.NsPerOp, base.NsPerOp, timeDelta, result.AllocsPerOp, base.AllocsPerOp, allocsDelta, mark)
+		}
+	}
+	w.Flush()
+
+	if *save {
+		if err := saveBenchReport(*baselinePath, report); err != nil {
+			return err
+		}
+		fmt.Printf("Saved %v results to %v\n", len(report.Results), *baselinePath)
+	} else if baseline == nil {
+		fmt.Printf("No baseline at %v. Run with --save to create one\n", *baselinePath)
+	}
+	if regressions > 0 {
+		return fmt.Errorf("%v benchmarks regressed by more than %v%%", regressions, *threshold)
+	}
+	return nil
+}
+
+// percentChange returns the change from base to value as a percentage of base.
+func percentChange(base int64, value int64) float64 {
+	if base == 0 {
+		if value == 0 {
+			return 0
+		}
+		return 100
+	}
+	return float64(value-base) / float64(base) * 100
+}
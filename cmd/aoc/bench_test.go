@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+
+	"aoc/solver"
+)
+
+// BenchmarkDays benchmarks parsing and solving both parts of every input file
+// of every day, e.g. -bench 'Days/day4/input.txt/part2'.
+func BenchmarkDays(b *testing.B) {
+	for _, day := range solver.Days() {
+		benchmarks, err := dayBenchmarks(day, dayDir(day.Number))
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, bm := range benchmarks {
+			b.Run("day"+strconv.Itoa(day.Number)+"/"+bm.Name, bm.F)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"aoc/solver"
+)
+
+// cacheDirEnv overrides the directory answers are cached in
+const cacheDirEnv = "AOC_CACHE_DIR"
+
+// answerCache stores answers on disk, keyed by everything they depend on: the
+// day, part, arguments and input, and the build ID of the aoc executable,
+// which changes whenever any solver's code does. The files the solver read
+// for its arguments are checked before a cached answer is used.
+type answerCache struct {
+	dir     string
+	buildID string
+}
+
+// cachedAnswer is the file stored for each key.
+type cachedAnswer struct {
+	Day         int             `json:"day"`
+	Part        int             `json:"part"`
+	Args        []string        `json:"args,omitempty"`
+	InputSHA256 string          `json:"inputSha256"`
+	BuildID     string          `json:"buildId"`
+	Answer      int             `json:"answer"`
+	Details     json.RawMessage `json:"details,omitempty"`
+	// Files holds the SHA-256 hash of each file read for the arguments, by name
+	Files map[string]string `json:"files,omitempty"`
+}
+
+func cacheDir() (string, error) {
+	if dir := os.Getenv(cacheDirEnv); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "answers"), nil
+}
+
+var buildID = sync.OnceValues(func() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+})
+
+func openAnswerCache() (*answerCache, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	id, err := buildID()
+	if err != nil {
+		return nil, fmt.Errorf("finding the build ID: %w", err)
+	}
+	return &answerCache{dir: dir, buildID: id}, nil
+}
+
+// entry returns the cache entry for solving a part of the input, without its answer.
+func (c *answerCache) entry(day int, part int, args []string, in []byte) cachedAnswer {
+	return cachedAnswer{Day: day, Part: part, Args: args, InputSHA256: sha256Hex(in), BuildID: c.buildID}
+}
+
+func (c *answerCache) path(entry cachedAnswer) string {
+	key, _ := json.Marshal(cachedAnswer{Day: entry.Day, Part: entry.Part, Args: entry.Args, InputSHA256: entry.InputSHA256, BuildID: entry.BuildID})
+	hash := sha256.Sum256(key)
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:])+".json")
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// readFile reads files for a solver's arguments like os.ReadFile, recording
+// their hashes in the entry so that the answer is only reused while they are unchanged.
+func (entry *cachedAnswer) readFile(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Files == nil {
+		entry.Files = make(map[string]string)
+	}
+	entry.Files[name] = sha256Hex(data)
+	return data, nil
+}
+
+// get returns the cached answer for the entry, if there is one and the files
+// read for it are unchanged.
+func (c *answerCache) get(entry cachedAnswer) (solver.Answer, bool) {
+	data, err := os.ReadFile(c.path(entry))
+	if err != nil {
+		return solver.Answer{}, false
+	}
+	var cached cachedAnswer
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return solver.Answer{}, false
+	}
+	for name, hash := range cached.Files {
+		if data, err := os.ReadFile(name); err != nil || sha256Hex(data) != hash {
+			return solver.Answer{}, false
+		}
+	}
+	answer := solver.Answer{Value: cached.Answer}
+	if cached.Details != nil {
+		answer.Details = cached.Details
+	}
+	return answer, true
+}
+
+// put caches the answer for the entry.
+func (c *answerCache) put(entry cachedAnswer, answer solver.Answer) error {
+	entry.Answer = answer.Value
+	if answer.Details != nil {
+		details, err := json.Marshal(answer.Details)
+		if err != nil {
+			return err
+		}
+		entry.Details = details
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file first, so concurrent runs never read a partial answer
+	tmp, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(entry))
+}
+
+func cacheCommand(args []string) error {
+	if len(args) != 1 || args[0] != "clear" {
+		return fmt.Errorf("invalid arguments. Expected cache clear")
+	}
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		fmt.Println("Cache is already empty")
+		return nil
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	fmt.Printf("Cleared %v\n", dir)
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"aoc/solver"
+)
+
+func TestAnswerCache(t *testing.T) {
+	cache := &answerCache{dir: t.TempDir(), buildID: "build1"}
+	entry := cache.entry(6, 1, nil, []byte("Time: 7\nDistance: 9\n"))
+	if _, found := cache.get(entry); found {
+		t.Fatal("got an answer from an empty cache")
+	}
+	if err := cache.put(entry, solver.Answer{Value: 4, Details: []int{4}}); err != nil {
+		t.Fatal(err)
+	}
+	answer, found := cache.get(entry)
+	if !found || answer.Value != 4 {
+		t.Fatalf("got %v, %v, expected the cached answer 4", answer, found)
+	}
+	if details, err := json.Marshal(answer.Details); err != nil || string(details) != "[4]" {
+		t.Errorf("got details %s, expected [4]", details)
+	}
+
+	misses := map[string]cachedAnswer{
+		"input":    cache.entry(6, 1, nil, []byte("Time: 7\nDistance: 10\n")),
+		"part":     cache.entry(6, 2, nil, []byte("Time: 7\nDistance: 9\n")),
+		"args":     cache.entry(6, 1, []string{"true"}, []byte("Time: 7\nDistance: 9\n")),
+		"build ID": (&answerCache{dir: cache.dir, buildID: "build2"}).entry(6, 1, nil, []byte("Time: 7\nDistance: 9\n")),
+	}
+	for name, entry := range misses {
+		if _, found := cache.get(entry); found {
+			t.Errorf("got a cached answer after changing the %v", name)
+		}
+	}
+}
+
+func TestAnswerCacheArgFiles(t *testing.T) {
+	cache := &answerCache{dir: t.TempDir(), buildID: "build1"}
+	bagsPath := filepath.Join(t.TempDir(), "bags.txt")
+	if err := os.WriteFile(bagsPath, []byte("12 red\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	in := []byte("Game 1: 3 red\n")
+	args := []string{bagsPath}
+
+	entry := cache.entry(2, 1, args, in)
+	if data, err := entry.readFile(bagsPath); err != nil || string(data) != "12 red\n" {
+		t.Fatalf("got %q (%v), expected the bags", data, err)
+	}
+	if err := cache.put(entry, solver.Answer{Value: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if answer, found := cache.get(cache.entry(2, 1, args, in)); !found || answer.Value != 1 {
+		t.Fatalf("got %v, %v, expected the cached answer 1", answer, found)
+	}
+
+	// The same arguments name a file with different contents
+	if err := os.WriteFile(bagsPath, []byte("2 red\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := cache.get(cache.entry(2, 1, args, in)); found {
+		t.Errorf("got a cached answer after changing a file named by the arguments")
+	}
+	if err := os.Remove(bagsPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := cache.get(cache.entry(2, 1, args, in)); found {
+		t.Errorf("got a cached answer after removing a file named by the arguments")
+	}
+}
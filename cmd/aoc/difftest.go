@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+
+	"aoc/solver"
+)
+
+// disagreement is an input on which a day's solver and reference solver give different answers.
+type disagreement struct {
+	Day       int
+	Part      int
+	Seed      int64
+	Size      int
+	Args      []string
+	Input     string
+	Answer    string
+	Reference string
+}
+
+func (d disagreement) String() string {
+	args := ""
+	if len(d.Args) > 0 {
+		args = fmt.Sprintf(" and arguments %#v", d.Args)
+	}
+	return fmt.Sprintf("day %v part %v: solvers disagree on the input generated with --seed=%v --size=%v%v\n  solver:    %v\n  reference: %v\nminimised input:\n%v",
+		d.Day, d.Part, d.Seed, d.Size, args, d.Answer, d.Reference, d.Input)
+}
+
+// outcome is the answer a solver gave for a part, or its error.
+type outcome struct {
+	answer solver.Answer
+	err    error
+}
+
+func (o outcome) String() string {
+	if o.err != nil {
+		return "error: " + o.err.Error()
+	}
+	return o.answer.String()
+}
+
+// solveOutcome solves a part with the solver from newSolver. Panics are reported as errors.
+func solveOutcome(newSolver func(solver.Options) (solver.Solver, error), part int, in string, args []string) (o outcome) {
+	defer func() {
+		if v := recover(); v != nil {
+			o = outcome{err: fmt.Errorf("solver panicked: %v", v)}
+		}
+	}()
+	s, err := newSolver(solver.Options{Args: args, ReadFile: os.ReadFile})
+	if err == nil {
+		err = s.Parse(strings.NewReader(in))
+	}
+	if err != nil {
+		return outcome{err: err}
+	}
+	answer, err := solver.SolvePart(s, part)
+	return outcome{answer: answer, err: err}
+}
+
+// compare solves a part of the input with both of the day's solvers. Inputs
+// too big for the reference can't be checked.
+func compare(day solver.Day, part int, in string, args []string) (answer outcome, reference outcome, checked bool) {
+	reference = solveOutcome(day.Reference, part, in, args)
+	if errors.Is(reference.err, solver.ErrTooBig) {
+		return outcome{}, reference, false
+	}
+	return solveOutcome(day.NewSolver, part, in, args), reference, true
+}
+
+// disagree returns whether the outcomes differ. As the inputs are valid, the
+// reference failing any other way than being too big is always a disagreement.
+func disagree(answer outcome, reference outcome) bool {
+	return reference.err != nil || answer.String() != reference.String()
+}
+
+// minimise removes as many lines from the input as it can while fails still
+// returns true, trying big chunks of lines first.
+func minimise(in string, fails func(string) bool) string {
+	lines := strings.SplitAfter(in, "\n")
+	for chunk := len(lines) / 2; chunk >= 1; {
+		removed := false
+		for start := 0; start < len(lines); {
+			candidate := append(slices.Clone(lines[:start]), lines[min(start+chunk, len(lines)):]...)
+			if fails(strings.Join(candidate, "")) {
+				lines = candidate
+				removed = true
+			} else {
+				start += chunk
+			}
+		}
+		if !removed {
+			chunk /= 2
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// findDisagreement compares the day's solvers on inputs generated from seeds
+// first to first+seeds-1, with sizes cycling from 1 to maxSize, or the day's
+// MaxSize if smaller, and with args
+// or else cycling through the day's ReferenceArgs. It returns the first
+// disagreement found with its input minimised, or nil, along with the number
+// of parts that were too big to check.
+func findDisagreement(day solver.Day, args []string, first int64, seeds int, maxSize int) (*disagreement, int) {
+	skipped := 0
+	if day.MaxSize > 0 {
+		maxSize = min(maxSize, day.MaxSize)
+	}
+	for i := 0; i < seeds; i++ {
+		seed := first + int64(i)
+		size := 1 + i%maxSize
+		args := args
+		if args == nil && len(day.ReferenceArgs) > 0 {
+			args = day.ReferenceArgs[i%len(day.ReferenceArgs)]
+		}
+		in := day.Generate(rand.New(rand.NewSource(seed)), size)
+		for part := 1; part <= 2; part++ {
+			answer, reference, checked := compare(day, part, in, args)
+			if !checked {
+				skipped++
+				continue
+			}
+			if !disagree(answer, reference) {
+				continue
+			}
+			// Removing lines often makes the input invalid, so a smaller input
+			// only counts if the reference still succeeds, or fails the same way
+			part, failure := part, reference
+			in = minimise(in, func(candidate string) bool {
+				answer, reference, checked := compare(day, part, candidate, args)
+				if !checked || !disagree(answer, reference) {
+					return false
+				}
+				if failure.err != nil {
+					return reference.String() == failure.String()
+				}
+				return reference.err == nil
+			})
+			answer, reference, _ = compare(day, part, in, args)
+			return &disagreement{Day: day.Number, Part: part, Seed: seed, Size: size, Args: args, Input: in, Answer: answer.String(), Reference: reference.String()}, skipped
+		}
+	}
+	return nil, skipped
+}
+
+func difftestCommand(args []string) error {
+	flags := flag.NewFlagSet("difftest", flag.ContinueOnError)
+	first := flags.Int64("seed", 1, "seed of the first generated input")
+	seeds := flags.Int("seeds", 100, "number of inputs to generate")
+	maxSize := flags.Int("max-size", 5, "largest size of the generated inputs")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 || *seeds < 1 || *maxSize < 1 {
+		return fmt.Errorf("invalid arguments. Expected difftest [flags] <day> [args...]")
+	}
+	number, err := solver.ParseDay(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	day, found := solver.Lookup(number)
+	if !found {
+		return fmt.Errorf("day %v has no registered solver", number)
+	}
+	if day.Generate == nil || day.Reference == nil {
+		return fmt.Errorf("day %v has no input generator and reference solver to test against", number)
+	}
+	dayArgs, err := day.ResolveArgs(flags.Args()[1:])
+	if err != nil {
+		return err
+	}
+	if _, err := day.NewSolver(solver.Options{Args: dayArgs, ReadFile: os.ReadFile}); err != nil {
+		return err
+	}
+
+	d, skipped := findDisagreement(day, dayArgs, *first, *seeds, *maxSize)
+	if d != nil {
+		fmt.Println(d)
+		return fmt.Errorf("day %v: solvers disagree", number)
+	}
+	fmt.Printf("Day %v: solvers agree on %v generated inputs (%v parts skipped as too big for the reference solver)\n", number, *seeds, skipped)
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"aoc/solver"
+)
+
+// TestReferenceSolvers checks every day's solver against its reference solver on generated inputs.
+func TestReferenceSolvers(t *testing.T) {
+	for _, day := range solver.Days() {
+		if day.Generate == nil || day.Reference == nil {
+			continue
+		}
+		if d, _ := findDisagreement(day, nil, 1, 20, 3); d != nil {
+			t.Error(d)
+		}
+	}
+}
+
+func TestMinimise(t *testing.T) {
+	in := "a\nb\nbad\nc\nworse\nd\n"
+	got := minimise(in, func(candidate string) bool {
+		return strings.Contains(candidate, "bad") && strings.Contains(candidate, "worse")
+	})
+	if got != "bad\nworse\n" {
+		t.Errorf("got %#v, expected %#v", got, "bad\nworse\n")
+	}
+}
+
+// fixedSolver answers both parts the same way, whatever the input.
+type fixedSolver struct {
+	answer int
+	err    error
+}
+
+func (s fixedSolver) Parse(r io.Reader) error       { return nil }
+func (s fixedSolver) Part1() (solver.Answer, error) { return solver.Answer{Value: s.answer}, s.err }
+func (s fixedSolver) Part2() (solver.Answer, error) { return solver.Answer{Value: s.answer}, s.err }
+
+func fixedDay(answer solver.Solver, reference solver.Solver) solver.Day {
+	return solver.Day{
+		Number:    25,
+		New:       func(solver.Options) (solver.Solver, error) { return answer, nil },
+		Generate:  func(rng *rand.Rand, size int) string { return "a\nb\n" },
+		Reference: func(solver.Options) (solver.Solver, error) { return reference, nil },
+	}
+}
+
+func TestReferenceErrors(t *testing.T) {
+	tooBig := fmt.Errorf("%w: 1000 steps", solver.ErrTooBig)
+	d, skipped := findDisagreement(fixedDay(fixedSolver{answer: 1}, fixedSolver{err: tooBig}), nil, 1, 5, 1)
+	if d != nil || skipped != 10 {
+		t.Errorf("got %v with %v skipped, expected every part skipped as too big", d, skipped)
+	}
+
+	// The reference failing any other way is a disagreement, even if the solver fails the same way
+	broken := errors.New("unknown node AAA")
+	for _, answer := range []fixedSolver{{answer: 1}, {err: broken}} {
+		d, _ := findDisagreement(fixedDay(answer, fixedSolver{err: broken}), nil, 1, 5, 1)
+		if d == nil || d.Reference != "error: unknown node AAA" {
+			t.Errorf("solver %+v: got %v, expected the reference's error as a disagreement", answer, d)
+		}
+	}
+}
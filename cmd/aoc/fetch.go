@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"aoc/cmd/internal/site"
+	"aoc/solver"
+)
+
+func parseYear(s string) (int, error) {
+	year, err := strconv.Atoi(s)
+	if err != nil || year < 2015 {
+		return 0, fmt.Errorf("invalid year %#v. Expected 2015 or later", s)
+	}
+	return year, nil
+}
+
+// fetchCommand downloads the input for a day into the day's directory,
+// unless it has already been downloaded.
+func fetchCommand(args []string) error {
+	flags := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	baseURL := flags.String("base-url", site.BaseURL(), "base URL of the Advent of Code site")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+	if len(args) != 2 {
+		return fmt.Errorf("invalid arguments. Expected fetch <year> <day>")
+	}
+	year, err := parseYear(args[0])
+	if err != nil {
+		return err
+	}
+	day, err := solver.ParseDay(args[1])
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join("day"+strconv.Itoa(day), defaultInputName)
+	if _, err := os.Stat(path); err == nil {
+		// Checked up front so that no session token is needed
+		slog.Info("input already cached", "year", year, "day", day, "path", path)
+		fmt.Println(path)
+		return nil
+	}
+	session, err := site.LoadSession()
+	if err != nil {
+		return err
+	}
+	client := site.NewClient(*baseURL, session)
+	fetched, err := client.CacheInput(context.Background(), year, day, path)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		slog.Info("downloaded input", "year", year, "day", day, "path", path)
+	} else {
+		slog.Info("input already cached", "year", year, "day", day, "path", path)
+	}
+	fmt.Println(path)
+	return nil
+}
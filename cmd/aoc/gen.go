@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+
+	"aoc/solver"
+)
+
+func genCommand(args []string) error {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	seed := flags.Int64("seed", 1, "seed for the random number generator")
+	size := flags.Int("size", 0, "size of the puzzle, whose meaning depends on the day (default: the day's own default)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	// Flags may also follow the day
+	if flags.NArg() < 1 {
+		return fmt.Errorf("invalid arguments. Expected gen [flags] <day>")
+	}
+	dayArg := flags.Arg(0)
+	if err := flags.Parse(flags.Args()[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("invalid arguments. Expected gen [flags] <day>")
+	}
+
+	number, err := solver.ParseDay(dayArg)
+	if err != nil {
+		return err
+	}
+	day, found := solver.Lookup(number)
+	if !found {
+		return fmt.Errorf("day %v has no registered solver", number)
+	}
+	if day.Generate == nil {
+		return fmt.Errorf("day %v has no input generator", number)
+	}
+	if day.MaxSize > 0 && *size > day.MaxSize {
+		return fmt.Errorf("invalid --size %v for day %v. Expected at most %v", *size, number, day.MaxSize)
+	}
+	fmt.Print(day.Generate(rand.New(rand.NewSource(*seed)), *size))
+	return nil
+}
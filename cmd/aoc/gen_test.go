@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"aoc/solver"
+)
+
+// TestGeneratedInputs checks that every day solves its own generated inputs.
+func TestGeneratedInputs(t *testing.T) {
+	for _, day := range solver.Days() {
+		if day.Generate == nil {
+			continue
+		}
+		for _, size := range []int{1, 2, 5, 20} {
+			for seed := int64(1); seed <= 5; seed++ {
+				day, size, seed := day, size, seed
+				t.Run("day"+strconv.Itoa(day.Number)+"/size"+strconv.Itoa(size)+"/seed"+strconv.FormatInt(seed, 10), func(t *testing.T) {
+					generated := day.Generate(rand.New(rand.NewSource(seed)), size)
+					if generated != day.Generate(rand.New(rand.NewSource(seed)), size) {
+						t.Fatal("generated a different input from the same seed")
+					}
+					for part := 1; part <= 2; part++ {
+						_, err := day.SolveReader(part, strings.NewReader(generated), solver.Options{})
+						if err != nil && !errors.Is(err, solver.ErrNotImplemented) {
+							t.Fatalf("part %v: %v\n%v", part, err, generated)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestGenMaxSize(t *testing.T) {
+	for _, day := range solver.Days() {
+		if day.Generate == nil || day.MaxSize == 0 {
+			continue
+		}
+		err := genCommand([]string{"--size=" + strconv.Itoa(day.MaxSize+1), strconv.Itoa(day.Number)})
+		if expected := "Expected at most " + strconv.Itoa(day.MaxSize); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("day %v: got error %v, expected %#v", day.Number, err, expected)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"aoc/solver"
+)
+
+var record = flag.Bool("record", false, "record answers for input files missing from each day's answers file, to be checked against the puzzle")
+
+// rootDir is the workspace root, relative to this package's directory
+const rootDir = "../.."
+
+func dayDir(number int) string {
+	return filepath.Join(rootDir, "day"+strconv.Itoa(number))
+}
+
+// TestGoldenAnswers checks every solver against the expected answers recorded
+// in its day's answers file. Run with -record to add answers for new input files.
+func TestGoldenAnswers(t *testing.T) {
+	for _, day := range solver.Days() {
+		dir := dayDir(day.Number)
+		answers, err := solver.LoadAnswers(dir)
+		if err != nil {
+			t.Fatalf("day %v: %v", day.Number, err)
+		}
+
+		for _, expected := range answers {
+			day, expected := day, expected
+			name := filepath.Join("day"+strconv.Itoa(day.Number), expected.Input, "part"+strconv.Itoa(expected.Part))
+			if len(expected.Args) > 0 {
+				name += "/" + strings.Join(expected.Args, ",")
+			}
+			t.Run(name, func(t *testing.T) {
+				answer, err := day.Solve(expected.Part, filepath.Join(dir, expected.Input), solver.Options{Args: expected.Args, ReadFile: os.ReadFile})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if answer.Value != expected.Answer {
+					t.Errorf("got %v, expected %v", answer, expected.Answer)
+				}
+			})
+		}
+
+		if *record {
+			recordAnswers(t, day, dir, answers)
+		}
+	}
+}
+
+func recordAnswers(t *testing.T, day solver.Day, dir string, answers []solver.ExpectedAnswer) {
+	inputPaths, err := filepath.Glob(filepath.Join(dir, "input*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := 0
+	for _, inputPath := range inputPaths {
+		input := filepath.Base(inputPath)
+		for _, part := range []int{1, 2} {
+			if _, found := solver.FindAnswer(answers, input, part, nil); found {
+				continue
+			}
+			answer, err := day.Solve(part, inputPath, solver.Options{})
+			if err != nil {
+				if !errors.Is(err, solver.ErrNotImplemented) {
+					t.Logf("day %v: not recording %v part %v: %v", day.Number, input, part, err)
+				}
+				continue
+			}
+			answers = append(answers, solver.ExpectedAnswer{Input: input, Part: part, Answer: answer.Value})
+			t.Logf("day %v: recorded %v part %v = %v. Check it against the puzzle", day.Number, input, part, answer.Value)
+			recorded++
+		}
+	}
+	if recorded == 0 {
+		return
+	}
+	if err := solver.SaveAnswers(dir, answers); err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("day %v: recorded %v new answers", day.Number, recorded)
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+)
+
+// newLogger returns a logger writing to stderr. Only warnings and errors are
+// shown by default, so that stdout holds just the answers.
+func newLogger(quiet, verbose, veryVerbose bool) *slog.Logger {
+	level := slog.LevelWarn
+	switch {
+	case veryVerbose:
+		level = slog.LevelDebug
+	case verbose:
+		level = slog.LevelInfo
+	case quiet:
+		level = slog.LevelError
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	_ "aoc/cmd/internal/days"
+)
+
+const usage = `Usage:
+  aoc [-q|-v|-vv] <command> [args...]
+
+Commands:
+  aoc run [--format=text|json] [--no-cache] <day> <part> <inputPath> [args...]
+  aoc run [--format=text|json] [--no-cache] [--jobs=N] all [inputName]
+  aoc fetch [--base-url=URL] <year> <day>
+  aoc submit [--base-url=URL] [--year=2023] <day> <part>
+  aoc new <day>
+  aoc bench [--baseline=benchmarks.json] [--save] [--threshold=10] [--run=REGEXP] [--benchtime=1s]
+  aoc serve [--addr=localhost:8080] [--timeout=30s] [--max-input-size=BYTES] [--max-solves=N]
+  aoc gen <day> [--seed=1] [--size=N]
+  aoc difftest [--seed=1] [--seeds=100] [--max-size=5] <day> [args...]
+  aoc cache clear
+  aoc watch [--interval=500ms] <day> <part> <inputPath> [args...]
+
+Options:
+  -q   only log errors
+  -v   log progress
+  -vv  log debug traces from the solvers
+
+An inputPath of "-" reads from stdin, and gzip-compressed input is
+decompressed automatically. A day's extra arguments follow the inputPath,
+in order or by name as --name=value, e.g. to choose the digit words of
+day 1 from the presets en, de, fr and roman, or a .json or .toml file:
+
+  aoc run 1 2 day1/input.txt --vocabulary=de
+
+run all solves every day's input concurrently, up to --jobs at once
+(default: the number of CPUs), and prints a table of the answers, times and
+heap allocations. Allocation counts are only exact with --jobs=1, as they
+include anything else allocating at the same time. A failing or panicking
+solver is reported without stopping the others.
+
+run caches answers in aoc/answers in the user cache directory, or
+$AOC_CACHE_DIR, keyed by the day, part, arguments, a SHA-256 hash of the
+input and the build ID of aoc, so a rebuilt solver is always rerun, as is
+one whose arguments name a file that has changed. Cached answers are
+reported without a time. --no-cache solves every part afresh, and cache
+clear deletes every cached answer.
+
+With --format=json, run prints one JSON object per result with the fields
+day, part, input, answer, duration (in nanoseconds), allocs, allocBytes,
+and cached, details or error where present. The details explain how some
+days found their answers, e.g. day 1 lists the tokens matched in each line
+given "all" as its explain argument, or only in the lines where matching
+words changes the value given "disagree":
+
+  aoc run --format=json 1 2 day1/input.txt --explain=disagree
+
+Day 2 checks several bags at once given a list separated by ";", or a .txt
+file of bags, one a line, and reports the possible games for each, and the
+draw that rules out each impossible game:
+
+  aoc run --format=json 2 1 day2/input.txt "12 red, 13 green, 14 blue; 5 red, 5 green, 5 blue"
+
+fetch downloads a day's input to dayN/input.txt, unless it is already
+there. The session token is read from $AOC_SESSION, or else from the file
+aoc/session in the user config directory. $AOC_BASE_URL overrides the site.
+
+submit solves a part using dayN/input.txt and submits the answer, recording
+the outcome in dayN/submissions.json. Answers already rejected, or outside
+the bounds of earlier too high/too low answers, are not resubmitted.
+
+new creates the module for a new day from a template, adds it to go.work and
+the solver registry, and creates empty answers.json and input_simple.txt
+files. It must be run from the workspace root.
+
+bench benchmarks parsing and solving each part of every day's input files,
+and compares the results with the baseline file, reporting a regression if
+the time or allocations per operation grew by more than the threshold
+percentage. --save replaces the baseline with the new results.
+
+serve answers POST /days/{day}/parts/{part} with the puzzle input as the
+request body, returning the result as JSON. Day-specific arguments are
+passed as query parameters, e.g. /days/2/parts/1?inputSet=12%20red and
+/days/11/parts/2?expansionFactor=100. Parameters can't name files, so e.g.
+day 1's vocabulary must be a preset. GET /days lists the days and their
+parameters. At most --max-solves solvers run at once, including those that
+timed out, and further requests get 503 until one finishes.
+
+gen prints a random puzzle input for a day, for stress testing the solvers.
+The same seed always gives the same input. What --size controls depends on
+the day, e.g. the number of games for day 2 or the width of the grid for
+day 10; by default the input is about as big as the real one. Day 6 is
+limited to 4 races and day 8 to 6 ghosts, so that their answers fit in an
+int.
+
+difftest checks a day's solver against its reference solver, which finds the
+same answers by other, usually brute-force, means, on --seeds generated inputs
+of sizes 1 to --max-size. The first input they disagree on is cut down to as
+few lines as still show the disagreement, and printed. Inputs too big for the
+reference solver are skipped, but any other error or panic from it counts as
+a disagreement. Without args, days whose defaults are too big for the
+reference, like day 11's expansion factor, are checked with smaller ones.
+
+watch rebuilds aoc and re-runs a part whenever the day's .go files, the
+shared packages, the input or its answers.json change, checking for changes
+every --interval. Each answer is compared with the previous run's and with
+the expected answer, if it is recorded. It must be run from the workspace
+root.`
+
+func run() error {
+	flags := flag.NewFlagSet("aoc", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprintln(flags.Output(), usage) }
+	quiet := flags.Bool("q", false, "only log errors")
+	verbose := flags.Bool("v", false, "log progress")
+	veryVerbose := flags.Bool("vv", false, "log debug traces from the solvers")
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	slog.SetDefault(newLogger(*quiet, *verbose, *veryVerbose))
+
+	args := flags.Args()
+	if len(args) < 1 {
+		return fmt.Errorf("no command given\n%v", usage)
+	}
+	switch args[0] {
+	case "run":
+		return runCommand(args[1:])
+	case "fetch":
+		return fetchCommand(args[1:])
+	case "submit":
+		return submitCommand(args[1:])
+	case "new":
+		return newCommand(args[1:])
+	case "bench":
+		return benchCommand(args[1:])
+	case "serve":
+		return serveCommand(args[1:])
+	case "gen":
+		return genCommand(args[1:])
+	case "difftest":
+		return difftestCommand(args[1:])
+	case "cache":
+		return cacheCommand(args[1:])
+	case "watch":
+		return watchCommand(args[1:])
+	case "help":
+		fmt.Println(usage)
+		return nil
+	}
+	return fmt.Errorf("unknown command %#v\n%v", args[0], usage)
+}
+
+func main() {
+	err := run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"aoc/solver"
+)
+
+//go:embed templates
+var templates embed.FS
+
+const (
+	goWorkFile = "go.work"
+	// daysFile imports every day so that its solver is registered
+	daysFile        = "cmd/internal/days/days.go"
+	sampleInputName = "input_simple.txt"
+)
+
+var goVersionRegexp = regexp.MustCompile(`(?m)^go (\S+)$`)
+
+type scaffold struct {
+	Package   string
+	Number    int
+	GoVersion string
+}
+
+// newCommand creates the module for a new day from the templates, and wires
+// it into go.work and the solver registry. It must be run from the
+// workspace root.
+func newCommand(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("invalid arguments. Expected new <day>")
+	}
+	number, err := solver.ParseDay(args[0])
+	if err != nil {
+		return err
+	}
+	dir := "day" + strconv.Itoa(number)
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%v already exists", dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	goWork, err := os.ReadFile(goWorkFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%v not found. Run aoc new from the workspace root", goWorkFile)
+	} else if err != nil {
+		return err
+	}
+	match := goVersionRegexp.FindSubmatch(goWork)
+	if match == nil {
+		return fmt.Errorf("%v has no go version", goWorkFile)
+	}
+	s := scaffold{Package: dir, Number: number, GoVersion: string(match[1])}
+
+	newGoWork, err := addWorkspaceUse(string(goWork), number)
+	if err != nil {
+		return err
+	}
+	days, err := os.ReadFile(daysFile)
+	if err != nil {
+		return err
+	}
+	newDays, err := addDayImport(days, number)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return err
+	}
+	if err := createDay(dir, s, []byte(newGoWork), newDays); err != nil {
+		// Leave the workspace as it was, so that new can simply be run again
+		if rollbackErr := errors.Join(
+			os.RemoveAll(dir),
+			os.WriteFile(goWorkFile, goWork, 0o644),
+			os.WriteFile(daysFile, days, 0o644),
+		); rollbackErr != nil {
+			return fmt.Errorf("%w (rolling back: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	fmt.Printf("Created %v\n", dir)
+	return nil
+}
+
+// createDay writes the files of the new day to dir, then wires it into the
+// workspace with the already edited go.work and days files.
+func createDay(dir string, s scaffold, goWork []byte, days []byte) error {
+	files := []struct {
+		name     string
+		template string
+	}{
+		{"go.mod", "go.mod.tmpl"},
+		{dir + ".go", "day.go.tmpl"},
+	}
+	for _, file := range files {
+		data, err := executeTemplate(file.template, s)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, file.name), data, 0o644); err != nil {
+			return err
+		}
+	}
+	if err := solver.SaveAnswers(dir, []solver.ExpectedAnswer{}); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, sampleInputName), nil, 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(goWorkFile, goWork, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(daysFile, days, 0o644)
+}
+
+func executeTemplate(name string, s scaffold) ([]byte, error) {
+	t, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, s); err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(name, ".go.tmpl") {
+		return format.Source(buf.Bytes())
+	}
+	return buf.Bytes(), nil
+}
+
+var dayUseRegexp = regexp.MustCompile(`^\s*\./day(\d+)\s*$`)
+
+// addWorkspaceUse adds ./dayN to the use block of a go.work file, keeping
+// the days in numerical order.
+func addWorkspaceUse(goWork string, number int) (string, error) {
+	lines := strings.Split(goWork, "\n")
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "use (" {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return "", fmt.Errorf("%v has no use block", goWorkFile)
+	}
+
+	// Insert after the last day with a lower number, or at the start of the block
+	insertAt := start + 1
+	for i := start + 1; i < len(lines) && strings.TrimSpace(lines[i]) != ")"; i++ {
+		match := dayUseRegexp.FindStringSubmatch(lines[i])
+		if match == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(match[1])
+		if n == number {
+			return "", fmt.Errorf("%v already uses ./day%v", goWorkFile, number)
+		}
+		if n < number {
+			insertAt = i + 1
+		}
+	}
+	lines = append(lines[:insertAt], append([]string{"\t./day" + strconv.Itoa(number)}, lines[insertAt:]...)...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// addDayImport adds a blank import of the day's package to the days file.
+func addDayImport(src []byte, number int) ([]byte, error) {
+	importLine := fmt.Sprintf("\t_ \"aoc/day%v\"\n", number)
+	if bytes.Contains(src, []byte(importLine)) {
+		return nil, fmt.Errorf("%v already imports day%v", daysFile, number)
+	}
+	i := bytes.Index(src, []byte("import (\n"))
+	if i < 0 {
+		return nil, fmt.Errorf("%v has no import block", daysFile)
+	}
+	i += len("import (\n")
+	result := append(append(append([]byte{}, src[:i]...), importLine...), src[i:]...)
+	// gofmt sorts the imports
+	return format.Source(result)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddWorkspaceUse(t *testing.T) {
+	tests := []struct {
+		name     string
+		goWork   string
+		number   int
+		expected string
+		err      string
+	}{
+		{
+			name:     "between days",
+			goWork:   "go 1.21.1\n\nuse (\n\t./day1\n\t./day3\n\t./cmd\n)\n",
+			number:   2,
+			expected: "go 1.21.1\n\nuse (\n\t./day1\n\t./day2\n\t./day3\n\t./cmd\n)\n",
+		},
+		{
+			name:     "numerical order",
+			goWork:   "go 1.21.1\n\nuse (\n\t./day1\n\t./day9\n\t./day11\n\t./cmd\n)\n",
+			number:   10,
+			expected: "go 1.21.1\n\nuse (\n\t./day1\n\t./day9\n\t./day10\n\t./day11\n\t./cmd\n)\n",
+		},
+		{
+			name:     "first day",
+			goWork:   "go 1.21.1\n\nuse (\n\t./cmd\n)\n",
+			number:   1,
+			expected: "go 1.21.1\n\nuse (\n\t./day1\n\t./cmd\n)\n",
+		},
+		{
+			name:     "last day",
+			goWork:   "go 1.21.1\n\nuse (\n\t./day1\n\t./cmd\n)\n",
+			number:   25,
+			expected: "go 1.21.1\n\nuse (\n\t./day1\n\t./day25\n\t./cmd\n)\n",
+		},
+		{
+			name:   "already used",
+			goWork: "go 1.21.1\n\nuse (\n\t./day1\n\t./day2\n)\n",
+			number: 2,
+			err:    "already uses ./day2",
+		},
+		{
+			name:   "no use block",
+			goWork: "go 1.21.1\n\nuse ./day1\n",
+			number: 2,
+			err:    "has no use block",
+		},
+	}
+	for _, test := range tests {
+		got, err := addWorkspaceUse(test.goWork, test.number)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%v: got error %v, expected %#v", test.name, err, test.err)
+			}
+		} else if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+		} else if got != test.expected {
+			t.Errorf("%v: got:\n%v\nexpected:\n%v", test.name, got, test.expected)
+		}
+	}
+}
+
+func TestAddDayImport(t *testing.T) {
+	const header = "// Package days imports every day.\npackage days\n\n"
+	tests := []struct {
+		name     string
+		src      string
+		number   int
+		expected string
+		err      string
+	}{
+		{
+			name:     "sorted",
+			src:      header + "import (\n\t_ \"aoc/day1\"\n\t_ \"aoc/day3\"\n)\n",
+			number:   2,
+			expected: header + "import (\n\t_ \"aoc/day1\"\n\t_ \"aoc/day2\"\n\t_ \"aoc/day3\"\n)\n",
+		},
+		{
+			name:     "sorted as strings",
+			src:      header + "import (\n\t_ \"aoc/day1\"\n\t_ \"aoc/day2\"\n)\n",
+			number:   10,
+			expected: header + "import (\n\t_ \"aoc/day1\"\n\t_ \"aoc/day10\"\n\t_ \"aoc/day2\"\n)\n",
+		},
+		{
+			name:   "already imported",
+			src:    header + "import (\n\t_ \"aoc/day1\"\n)\n",
+			number: 1,
+			err:    "already imports day1",
+		},
+		{
+			name:   "no import block",
+			src:    header + "import _ \"aoc/day1\"\n",
+			number: 2,
+			err:    "has no import block",
+		},
+	}
+	for _, test := range tests {
+		got, err := addDayImport([]byte(test.src), test.number)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%v: got error %v, expected %#v", test.name, err, test.err)
+			}
+		} else if err != nil {
+			t.Errorf("%v: %v", test.name, err)
+		} else if string(got) != test.expected {
+			t.Errorf("%v: got:\n%v\nexpected:\n%v", test.name, string(got), test.expected)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+const defaultInputName = "input.txt"
+
+type Result struct {
+	Day       int
+	Part      int
+	InputPath string
+	Answer    solver.Answer
+	Duration  time.Duration
+	// Allocs and AllocBytes count the heap allocations made while solving.
+	// They are only exact if no other solver was running at the same time.
+	Allocs     uint64
+	AllocBytes uint64
+	// Cached is set if the answer came from the answer cache rather than being solved
+	Cached bool
+	Err    error
+}
+
+func (r Result) String() string {
+	prefix := fmt.Sprintf("Day %2d part %v", r.Day, r.Part)
+	if r.Err != nil {
+		return fmt.Sprintf("%v: %v (%v)", prefix, r.Err, r.InputPath)
+	}
+	if r.Cached {
+		return fmt.Sprintf("%v: %v (%v, cached)", prefix, r.Answer, r.InputPath)
+	}
+	return fmt.Sprintf("%v: %v (%v, %v)", prefix, r.Answer, r.InputPath, r.Duration.Round(time.Microsecond))
+}
+
+type resultJSON struct {
+	Day     int    `json:"day"`
+	Part    int    `json:"part"`
+	Input   string `json:"input,omitempty"`
+	Answer  *int   `json:"answer,omitempty"`
+	Details any    `json:"details,omitempty"`
+	// Duration is in nanoseconds
+	Duration   int64  `json:"duration"`
+	Allocs     uint64 `json:"allocs"`
+	AllocBytes uint64 `json:"allocBytes"`
+	Cached     bool   `json:"cached,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (r Result) MarshalJSON() ([]byte, error) {
+	j := resultJSON{
+		Day:        r.Day,
+		Part:       r.Part,
+		Input:      r.InputPath,
+		Duration:   r.Duration.Nanoseconds(),
+		Allocs:     r.Allocs,
+		AllocBytes: r.AllocBytes,
+		Cached:     r.Cached,
+	}
+	if r.Err != nil {
+		j.Error = r.Err.Error()
+	} else {
+		j.Answer = &r.Answer.Value
+		j.Details = r.Answer.Details
+	}
+	return json.Marshal(j)
+}
+
+// printResult writes the result to stdout in the given format: "text" for a
+// human-readable line, or "json" for one JSON object per line.
+func printResult(format string, result Result) error {
+	if format != "json" {
+		fmt.Println(result)
+		return nil
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func parseFormat(s string) (string, error) {
+	switch s {
+	case "text", "json":
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid format %#v. Expected text/json", s)
+}
+
+// solve solves a part of a day with the input at inputPath, using the answer
+// cache if it isn't nil.
+func solve(cache *answerCache, day solver.Day, part int, inputPath string, args []string) Result {
+	r, err := input.Open(inputPath)
+	if err != nil {
+		return Result{Day: day.Number, Part: part, InputPath: inputPath, Err: err}
+	}
+	defer r.Close()
+	if cache == nil {
+		return solveReader(day, part, inputPath, r, args, os.ReadFile)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Result{Day: day.Number, Part: part, InputPath: inputPath, Err: err}
+	}
+	entry := cache.entry(day.Number, part, args, data)
+	if answer, found := cache.get(entry); found {
+		return Result{Day: day.Number, Part: part, InputPath: inputPath, Answer: answer, Cached: true}
+	}
+	result := solveReader(day, part, inputPath, bytes.NewReader(data), args, entry.readFile)
+	if result.Err == nil {
+		if err := cache.put(entry, result.Answer); err != nil {
+			slog.Warn("caching answer", "day", day.Number, "part", part, "err", err)
+		}
+	}
+	return result
+}
+
+// solveReader solves a part of a day with input from r, which was read from
+// inputPath if known. Files named by args are read with readFile, or rejected
+// if it is nil. A panicking solver is reported as an error.
+func solveReader(day solver.Day, part int, inputPath string, r io.Reader, args []string, readFile func(string) ([]byte, error)) (result Result) {
+	logger := slog.Default().With("day", day.Number, "part", part)
+	logger.Info("solving", "input", inputPath)
+	result = Result{Day: day.Number, Part: part, InputPath: inputPath}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+		runtime.ReadMemStats(&after)
+		result.Allocs = after.Mallocs - before.Mallocs
+		result.AllocBytes = after.TotalAlloc - before.TotalAlloc
+		if v := recover(); v != nil {
+			logger.Debug("solver panicked", "panic", v, "stack", string(debug.Stack()))
+			result.Err = fmt.Errorf("solver panicked: %v", v)
+		}
+	}()
+	result.Answer, result.Err = day.SolveReader(part, r, solver.Options{Args: args, Log: logger, ReadFile: readFile})
+	input.SetFile(result.Err, inputPath)
+	return result
+}
+
+func runCommand(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	formatFlag := flags.String("format", "text", "output format (text/json)")
+	jobs := flags.Int("jobs", runtime.GOMAXPROCS(0), "number of solvers run at once by run all")
+	noCache := flags.Bool("no-cache", false, "solve every part, ignoring and not updating the answer cache")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var cache *answerCache
+	if !*noCache {
+		var err error
+		cache, err = openAnswerCache()
+		if err != nil {
+			slog.Warn("not caching answers", "err", err)
+		}
+	}
+	format, err := parseFormat(*formatFlag)
+	if err != nil {
+		return err
+	}
+	args = flags.Args()
+
+	if len(args) >= 1 && args[0] == "all" {
+		return runAll(cache, format, *jobs, args[1:])
+	}
+	if len(args) < 3 {
+		return fmt.Errorf("invalid arguments. Expected run <day> <part> <inputPath> [args...]")
+	}
+	number, err := solver.ParseDay(args[0])
+	if err != nil {
+		return err
+	}
+	day, found := solver.Lookup(number)
+	if !found {
+		return fmt.Errorf("day %v has no registered solver", number)
+	}
+	part, err := solver.ParsePart(args[1])
+	if err != nil {
+		return err
+	}
+
+	dayArgs, err := day.ResolveArgs(args[3:])
+	if err != nil {
+		return fmt.Errorf("day %v: %w\nUsage: aoc run %v <part> <inputPath> %v", day.Number, err, day.Number, day.Usage())
+	}
+	result := solve(cache, day, part, args[2], dayArgs)
+	if result.Err != nil && format == "json" {
+		if err := printResult(format, result); err != nil {
+			return err
+		}
+		return fmt.Errorf("day %v part %v failed", day.Number, part)
+	}
+	if result.Err != nil {
+		if len(day.Args) > 0 {
+			return fmt.Errorf("day %v part %v: %w\nUsage: aoc run %v <part> <inputPath> %v", day.Number, part, result.Err, day.Number, day.Usage())
+		}
+		return fmt.Errorf("day %v part %v: %w", day.Number, part, result.Err)
+	}
+	return printResult(format, result)
+}
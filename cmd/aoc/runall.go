@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"aoc/solver"
+)
+
+type job struct {
+	day       solver.Day
+	part      int
+	inputPath string
+}
+
+// runAll solves both parts of every registered day using the input file of
+// the given name from each day's directory, running up to jobs solvers at once.
+func runAll(cache *answerCache, format string, jobs int, args []string) error {
+	inputName := defaultInputName
+	switch len(args) {
+	case 0:
+		break
+	case 1:
+		inputName = args[0]
+	default:
+		return fmt.Errorf("invalid arguments. Expected run all [inputName]")
+	}
+	if jobs < 1 {
+		return fmt.Errorf("invalid number of jobs %v. Expected at least 1", jobs)
+	}
+
+	var queue []job
+	for _, day := range solver.Days() {
+		inputPath := filepath.Join("day"+strconv.Itoa(day.Number), inputName)
+		for _, part := range []int{1, 2} {
+			queue = append(queue, job{day, part, inputPath})
+		}
+	}
+
+	start := time.Now()
+	results := solveAll(cache, queue, jobs)
+	elapsed := time.Since(start)
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, solver.ErrNotImplemented) {
+			failed++
+		}
+	}
+	if format == "json" {
+		for _, result := range results {
+			if err := printResult(format, result); err != nil {
+				return err
+			}
+		}
+	} else {
+		printTable(results, elapsed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%v of %v solvers failed", failed, len(results))
+	}
+	return nil
+}
+
+// solveAll runs the jobs on a pool of workers, returning the results in the
+// same order as the jobs.
+func solveAll(cache *answerCache, queue []job, workers int) []Result {
+	results := make([]Result, len(queue))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, len(queue)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = solve(cache, queue[i].day, queue[i].part, queue[i].inputPath, nil)
+			}
+		}()
+	}
+	for i := range queue {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// printTable prints the results as a table, followed by the total time
+// spent solving and the wall time taken to run every solver.
+func printTable(results []Result, elapsed time.Duration) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Day\tPart\tAnswer\tTime\tAllocs\tBytes\t")
+	var total time.Duration
+	for _, r := range results {
+		total += r.Duration
+		answer := r.Answer.String()
+		if r.Err != nil {
+			answer = "-"
+		}
+		if r.Cached {
+			fmt.Fprintf(w, "%v\t%v\t%v\tcached\t-\t-\t\n", r.Day, r.Part, answer)
+			continue
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t\n", r.Day, r.Part, answer, r.Duration.Round(time.Microsecond), r.Allocs, r.AllocBytes)
+	}
+	fmt.Fprintf(w, "Total\t\t\t%v\t\t\t\n", total.Round(time.Microsecond))
+	fmt.Fprintf(w, "Wall\t\t\t%v\t\t\t\n", elapsed.Round(time.Microsecond))
+	w.Flush()
+
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Printf("Day %v part %v: %v (%v)\n", r.Day, r.Part, r.Err, r.InputPath)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+// server serves the solvers over HTTP, accepting plain or gzip-compressed input:
+//
+//	GET  /days                      lists the days and the parameters each accepts
+//	POST /days/{day}/parts/{part}   solves a part, with the puzzle input as the body
+//
+// Day-specific arguments are given as query parameters named after day.Args.
+type server struct {
+	// timeout limits how long a request waits for its answer
+	timeout time.Duration
+	// maxInputSize limits the size of the input, in bytes, both as sent and decompressed
+	maxInputSize int64
+	// solves holds a token for each solver running, including those abandoned
+	// on timeout, so that its capacity limits how many run at once
+	solves chan struct{}
+}
+
+type errorJSON struct {
+	Error string `json:"error"`
+}
+
+type dayJSON struct {
+	Day  int      `json:"day"`
+	Args []string `json:"args,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("writing response", "err", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, format string, a ...any) {
+	writeJSON(w, status, errorJSON{Error: fmt.Sprintf(format, a...)})
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(segments) == 1 && segments[0] == "days":
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeError(w, http.StatusMethodNotAllowed, "method %v not allowed", r.Method)
+			return
+		}
+		s.serveDays(w)
+	case len(segments) == 4 && segments[0] == "days" && segments[2] == "parts":
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, "method %v not allowed", r.Method)
+			return
+		}
+		s.serveSolve(w, r, segments[1], segments[3])
+	default:
+		writeError(w, http.StatusNotFound, "no such endpoint %v", r.URL.Path)
+	}
+}
+
+func (s *server) serveDays(w http.ResponseWriter) {
+	days := solver.Days()
+	result := make([]dayJSON, len(days))
+	for i, day := range days {
+		result[i] = dayJSON{Day: day.Number, Args: day.Args}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (s *server) serveSolve(w http.ResponseWriter, r *http.Request, dayString string, partString string) {
+	number, err := solver.ParseDay(dayString)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+	day, found := solver.Lookup(number)
+	if !found {
+		writeError(w, http.StatusNotFound, "day %v has no registered solver", number)
+		return
+	}
+	part, err := solver.ParsePart(partString)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+	args, err := queryArgs(day, r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxInputSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeError(w, http.StatusRequestEntityTooLarge, "input larger than %v bytes", maxBytesErr.Limit)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusBadRequest, "reading input: %v", err)
+		return
+	}
+	decompressed, err := input.Decompress(bytes.NewReader(body))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "reading input: %v", err)
+		return
+	}
+	// A small compressed body can expand to any size, so the limit applies again
+	body, err = io.ReadAll(io.LimitReader(decompressed, s.maxInputSize+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "reading input: %v", err)
+		return
+	} else if int64(len(body)) > s.maxInputSize {
+		writeError(w, http.StatusRequestEntityTooLarge, "decompressed input larger than %v bytes", s.maxInputSize)
+		return
+	}
+
+	select {
+	case s.solves <- struct{}{}:
+	default:
+		writeError(w, http.StatusServiceUnavailable, "too many solves in progress, try again later")
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	// Solvers can't be interrupted, so on timeout the solver is abandoned and
+	// finishes in the background, still holding its token
+	done := make(chan Result, 1)
+	go func() {
+		// Arguments can't name files, so that requests can only read the body
+		result := solveReader(day, part, "", bytes.NewReader(body), args, nil)
+		<-s.solves
+		done <- result
+	}()
+	var result Result
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		slog.Warn("request timed out", "day", day.Number, "part", part, "timeout", s.timeout)
+		writeError(w, http.StatusServiceUnavailable, "day %v part %v took longer than %v", day.Number, part, s.timeout)
+		return
+	}
+
+	status := http.StatusOK
+	var badArgs *solver.ArgsError
+	switch {
+	case result.Err == nil:
+	case errors.As(result.Err, &badArgs):
+		status = http.StatusBadRequest
+	case errors.Is(result.Err, solver.ErrNotImplemented):
+		status = http.StatusNotImplemented
+	default:
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, result)
+}
+
+// queryArgs returns the day's extra arguments from the query parameters
+// named after them. As the arguments are positional, every argument before
+// the last one given must also be given.
+func queryArgs(day solver.Day, query url.Values) ([]string, error) {
+	known := make(map[string]bool)
+	for _, name := range day.Args {
+		known[name] = true
+	}
+	for name := range query {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown parameter %#v for day %v", name, day.Number)
+		}
+	}
+
+	var args []string
+	for i, name := range day.Args {
+		if !query.Has(name) {
+			for _, later := range day.Args[i+1:] {
+				if query.Has(later) {
+					return nil, fmt.Errorf("parameter %#v is required when %#v is given", name, later)
+				}
+			}
+			break
+		}
+		args = append(args, query.Get(name))
+	}
+	return args, nil
+}
+
+func serveCommand(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	timeout := flags.Duration("timeout", 30*time.Second, "maximum time to wait for an answer")
+	maxInputSize := flags.Int64("max-input-size", 1<<20, "maximum size of the puzzle input, in bytes")
+	maxSolves := flags.Int("max-solves", runtime.GOMAXPROCS(0), "maximum number of solvers running at once")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("invalid arguments. Expected serve [flags]")
+	}
+	if *maxSolves < 1 {
+		return fmt.Errorf("invalid --max-solves %v. Expected at least 1", *maxSolves)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           &server{timeout: *timeout, maxInputSize: *maxInputSize, solves: make(chan struct{}, *maxSolves)},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Printf("Listening on http://%v\n", *addr)
+	return httpServer.ListenAndServe()
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServe(t *testing.T) {
+	day11, err := os.ReadFile(filepath.Join(dayDir(11), "input_simple.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	day2, err := os.ReadFile(filepath.Join(dayDir(2), "input_simple.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(&server{timeout: 10 * time.Second, maxInputSize: 1 << 16, solves: make(chan struct{}, 4)})
+	defer server.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+		answer int
+	}{
+		{http.MethodPost, "/days/11/parts/2?expansionFactor=10", string(day11), http.StatusOK, 1030},
+		{http.MethodPost, "/days/11/parts/2?expansionFactor=100", string(day11), http.StatusOK, 8410},
+		{http.MethodPost, "/days/2/parts/1", string(day2), http.StatusOK, 8},
+		{http.MethodPost, "/days/2/parts/1?inputSet=" + "1%20red", string(day2), http.StatusOK, 0},
+		{http.MethodPost, "/days/2/parts/1?inputSet=" + filepath.Join(dayDir(2), "input_simple.txt"), string(day2), http.StatusBadRequest, 0},
+		{http.MethodPost, "/days/1/parts/2?useWords=&vocabulary=roman", "VI2\n", http.StatusOK, 62},
+		{http.MethodPost, "/days/1/parts/2?useWords=&vocabulary=" + filepath.Join(dayDir(1), "missing.json"), "1\n", http.StatusBadRequest, 0},
+		{http.MethodPost, "/days/11/parts/2?expansionFactor=x", string(day11), http.StatusBadRequest, 0},
+		{http.MethodPost, "/days/11/parts/2?bag=1", string(day11), http.StatusBadRequest, 0},
+		{http.MethodPost, "/days/11/parts/3", string(day11), http.StatusNotFound, 0},
+		{http.MethodPost, "/days/12/parts/1", "", http.StatusNotFound, 0},
+		{http.MethodPost, "/days/13/parts/1", "", http.StatusNotImplemented, 0},
+		{http.MethodGet, "/days/11/parts/1", "", http.StatusMethodNotAllowed, 0},
+		{http.MethodPost, "/days/11/parts/1", strings.Repeat(".", 1<<17), http.StatusRequestEntityTooLarge, 0},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, server.URL+test.path, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result struct {
+			Answer int    `json:"answer"`
+			Error  string `json:"error"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			t.Errorf("%v %v: decoding response: %v", test.method, test.path, err)
+			continue
+		}
+		if resp.StatusCode != test.status {
+			t.Errorf("%v %v: got status %v (%v), expected %v", test.method, test.path, resp.StatusCode, result.Error, test.status)
+		} else if result.Answer != test.answer {
+			t.Errorf("%v %v: got answer %v, expected %v", test.method, test.path, result.Answer, test.answer)
+		}
+	}
+}
+
+func gzipString(t *testing.T, s string) string {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func postStatus(t *testing.T, url string, body string) (int, string) {
+	resp, err := http.Post(url, "application/octet-stream", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var result errorJSON
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, result.Error
+}
+
+func TestServeCompressedInput(t *testing.T) {
+	day11, err := os.ReadFile(filepath.Join(dayDir(11), "input_simple.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(&server{timeout: 10 * time.Second, maxInputSize: 1 << 16, solves: make(chan struct{}, 1)})
+	defer server.Close()
+
+	if status, msg := postStatus(t, server.URL+"/days/11/parts/1", gzipString(t, string(day11))); status != http.StatusOK {
+		t.Errorf("compressed input: got status %v (%v), expected %v", status, msg, http.StatusOK)
+	}
+	// Well under the limit compressed, but far over it decompressed
+	bomb := gzipString(t, strings.Repeat(".", 1<<20))
+	if len(bomb) >= 1<<16 {
+		t.Fatalf("compressed input is %v bytes, expected under the limit", len(bomb))
+	}
+	status, msg := postStatus(t, server.URL+"/days/11/parts/1", bomb)
+	if status != http.StatusRequestEntityTooLarge || !strings.Contains(msg, "decompressed input larger than 65536 bytes") {
+		t.Errorf("compressed bomb: got status %v (%v), expected %v", status, msg, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestServeMaxSolves(t *testing.T) {
+	day11, err := os.ReadFile(filepath.Join(dayDir(11), "input_simple.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &server{timeout: 10 * time.Second, maxInputSize: 1 << 16, solves: make(chan struct{}, 1)}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	// A solver still running, e.g. one that timed out
+	s.solves <- struct{}{}
+	if status, msg := postStatus(t, server.URL+"/days/11/parts/1", string(day11)); status != http.StatusServiceUnavailable {
+		t.Errorf("with no solves free: got status %v (%v), expected %v", status, msg, http.StatusServiceUnavailable)
+	}
+	<-s.solves
+	for i := 0; i < 2; i++ {
+		if status, msg := postStatus(t, server.URL+"/days/11/parts/1", string(day11)); status != http.StatusOK {
+			t.Errorf("solve %v after it finished: got status %v (%v), expected %v", i+1, status, msg, http.StatusOK)
+		}
+	}
+}
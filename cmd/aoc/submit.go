@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"aoc/cmd/internal/site"
+	"aoc/solver"
+)
+
+const defaultYear = 2023
+
+// submitCommand solves a part of a day using the day's input, and submits
+// the answer unless the submission history shows it cannot be right.
+func submitCommand(args []string) error {
+	flags := flag.NewFlagSet("submit", flag.ContinueOnError)
+	baseURL := flags.String("base-url", site.BaseURL(), "base URL of the Advent of Code site")
+	year := flags.Int("year", defaultYear, "puzzle year")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+	if len(args) != 2 {
+		return fmt.Errorf("invalid arguments. Expected submit <day> <part>")
+	}
+	number, err := solver.ParseDay(args[0])
+	if err != nil {
+		return err
+	}
+	day, found := solver.Lookup(number)
+	if !found {
+		return fmt.Errorf("day %v has no registered solver", number)
+	}
+	part, err := solver.ParsePart(args[1])
+	if err != nil {
+		return err
+	}
+
+	dir := "day" + strconv.Itoa(day.Number)
+	result := solve(nil, day, part, filepath.Join(dir, defaultInputName), nil)
+	if result.Err != nil {
+		return fmt.Errorf("day %v part %v: %w", day.Number, part, result.Err)
+	}
+	fmt.Println(result)
+	answer := result.Answer.Value
+
+	history, err := site.LoadHistory(dir)
+	if err != nil {
+		return err
+	}
+	// Submissions recorded before years were are all for the default year
+	for i := range history {
+		if history[i].Year == 0 {
+			history[i].Year = defaultYear
+		}
+	}
+	if err := history.Check(*year, part, answer, time.Now()); err != nil {
+		return fmt.Errorf("not submitting: %w", err)
+	}
+
+	session, err := site.LoadSession()
+	if err != nil {
+		return err
+	}
+	client := site.NewClient(*baseURL, session)
+	slog.Info("submitting", "year", *year, "day", day.Number, "part", part, "answer", answer)
+	verdict, err := client.Submit(context.Background(), *year, day.Number, part, strconv.Itoa(answer))
+	if err != nil {
+		return err
+	}
+	slog.Info("submitted", "message", verdict.Message)
+
+	history = append(history, site.Submission{Year: *year, Part: part, Answer: answer, Outcome: verdict.Outcome, Time: time.Now(), Wait: verdict.Wait})
+	if err := site.SaveHistory(dir, history); err != nil {
+		return err
+	}
+
+	if verdict.Wait > 0 {
+		fmt.Printf("Answer %v: %v (wait %v)\n", answer, verdict.Outcome, verdict.Wait)
+	} else {
+		fmt.Printf("Answer %v: %v\n", answer, verdict.Outcome)
+	}
+	if verdict.Outcome != site.Correct {
+		return fmt.Errorf("answer not accepted: %v", verdict.Message)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"aoc/solver"
+)
+
+// sharedDirs hold the packages every day's solver is built from, besides its own
+var sharedDirs = []string{"input", "grid", "solver"}
+
+// fileState is what watch compares to notice that a file has changed.
+type fileState struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// snapshot returns the state of every file matched by patterns. Missing files are left out.
+func snapshot(patterns []string) (map[string]fileState, error) {
+	files := make(map[string]fileState)
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			files[path] = fileState{ModTime: info.ModTime(), Size: info.Size()}
+		}
+	}
+	return files, nil
+}
+
+// changedFiles returns the paths added, removed or modified between two snapshots, sorted.
+func changedFiles(before, after map[string]fileState) []string {
+	var changed []string
+	for path, state := range after {
+		if prev, found := before[path]; !found || prev != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, found := after[path]; !found {
+			changed = append(changed, path)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// watchRun is the outcome of one rebuild and run of a solver.
+type watchRun struct {
+	Answer   *int
+	Duration time.Duration
+	Err      string
+}
+
+func (r watchRun) String() string {
+	if r.Err != "" {
+		return r.Err
+	}
+	return fmt.Sprintf("%v (%v)", *r.Answer, r.Duration.Round(time.Microsecond))
+}
+
+// compareRuns describes how the run differs from the previous one, if there
+// was one, and from the expected answer, if it is known.
+func compareRuns(prev *watchRun, run watchRun, expected *int) []string {
+	var lines []string
+	if prev != nil {
+		switch {
+		case prev.Answer != nil && run.Answer != nil && *prev.Answer == *run.Answer:
+			lines = append(lines, "unchanged since the last run")
+		case prev.Answer != nil:
+			lines = append(lines, fmt.Sprintf("changed from %v", *prev.Answer))
+		case run.Answer != nil:
+			lines = append(lines, "fixed since the last run")
+		}
+	}
+	if expected != nil && run.Answer != nil {
+		if *run.Answer == *expected {
+			lines = append(lines, "matches the expected answer")
+		} else {
+			lines = append(lines, fmt.Sprintf("expected %v, off by %v", *expected, *run.Answer-*expected))
+		}
+	}
+	return lines
+}
+
+// expectedAnswer returns the answer recorded in the input's directory for the part and arguments, if any.
+func expectedAnswer(inputPath string, part int, args []string) (*int, error) {
+	answers, err := solver.LoadAnswers(filepath.Dir(inputPath))
+	if err != nil {
+		return nil, err
+	}
+	if answer, found := solver.FindAnswer(answers, filepath.Base(inputPath), part, args); found {
+		return &answer.Answer, nil
+	}
+	return nil, nil
+}
+
+// rebuildAndRun builds aoc from the workspace into bin, then runs the part
+// with it, so that the answer comes from the code as it is now.
+func rebuildAndRun(bin string, day int, part int, inputPath string, args []string) (watchRun, error) {
+	build := exec.Command("go", "build", "-o", bin, "./cmd/aoc")
+	if output, err := build.CombinedOutput(); err != nil {
+		return watchRun{}, fmt.Errorf("build failed:\n%s", bytes.TrimSpace(output))
+	}
+	runArgs := append([]string{"-q", "run", "--format=json", "--no-cache", strconv.Itoa(day), strconv.Itoa(part), inputPath}, args...)
+	cmd := exec.Command(bin, runArgs...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	var result resultJSON
+	if jsonErr := json.Unmarshal(output, &result); jsonErr != nil {
+		if err == nil {
+			err = jsonErr
+		}
+		return watchRun{}, fmt.Errorf("run failed: %w\n%s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return watchRun{Answer: result.Answer, Duration: time.Duration(result.Duration), Err: result.Error}, nil
+}
+
+// watchCommand rebuilds aoc and re-runs a part whenever the day's code, the
+// shared packages or the input change. It must be run from the workspace root.
+func watchCommand(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to check for changed files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+	if len(args) < 3 {
+		return fmt.Errorf("invalid arguments. Expected watch [--interval=500ms] <day> <part> <inputPath> [args...]")
+	}
+	number, err := solver.ParseDay(args[0])
+	if err != nil {
+		return err
+	}
+	part, err := solver.ParsePart(args[1])
+	if err != nil {
+		return err
+	}
+	inputPath := args[2]
+	day, found := solver.Lookup(number)
+	if !found {
+		return fmt.Errorf("day %v has no registered solver", number)
+	}
+	dayArgs, err := day.ResolveArgs(args[3:])
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(goWorkFile); errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%v not found. Run aoc watch from the workspace root", goWorkFile)
+	} else if err != nil {
+		return err
+	}
+	dir := "day" + strconv.Itoa(number)
+	if _, err := os.Stat(dir); err != nil {
+		return err
+	}
+
+	patterns := []string{filepath.Join(dir, "*.go"), inputPath, filepath.Join(filepath.Dir(inputPath), solver.AnswersFileName)}
+	for _, shared := range sharedDirs {
+		patterns = append(patterns, filepath.Join(shared, "*.go"))
+	}
+	tmp, err := os.MkdirTemp("", "aoc-watch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	bin := filepath.Join(tmp, "aoc")
+
+	var files map[string]fileState
+	var prev *watchRun
+	for first := true; ; first = false {
+		next, err := snapshot(patterns)
+		if err != nil {
+			return err
+		}
+		changed := changedFiles(files, next)
+		files = next
+		if len(changed) == 0 {
+			time.Sleep(*interval)
+			continue
+		}
+		if !first {
+			fmt.Printf("\n%v changed\n", strings.Join(changed, ", "))
+		}
+
+		run, err := rebuildAndRun(bin, number, part, inputPath, dayArgs)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		expected, err := expectedAnswer(inputPath, part, dayArgs)
+		if err != nil {
+			fmt.Printf("Reading expected answers: %v\n", err)
+		}
+		fmt.Printf("Day %2d part %v: %v\n", number, part, run)
+		for _, line := range compareRuns(prev, run, expected) {
+			fmt.Printf("  %v\n", line)
+		}
+		prev = &run
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.go", "package a")
+	write("b.go", "package b")
+	patterns := []string{filepath.Join(dir, "*.go"), filepath.Join(dir, "input.txt")}
+	before, err := snapshot(patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 2 {
+		t.Fatalf("got %v, expected a.go and b.go", before)
+	}
+
+	write("b.go", "package b // changed")
+	write("input.txt", "1")
+	if err := os.Remove(filepath.Join(dir, "a.go")); err != nil {
+		t.Fatal(err)
+	}
+	after, err := snapshot(patterns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := changedFiles(before, after)
+	expected := []string{filepath.Join(dir, "a.go"), filepath.Join(dir, "b.go"), filepath.Join(dir, "input.txt")}
+	if !slices.Equal(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+	if got := changedFiles(after, after); len(got) != 0 {
+		t.Errorf("got %v, expected no changes", got)
+	}
+}
+
+func TestCompareRuns(t *testing.T) {
+	answer := func(n int) *int { return &n }
+	tests := []struct {
+		prev     *watchRun
+		run      watchRun
+		expected *int
+		lines    []string
+	}{
+		{nil, watchRun{Answer: answer(288)}, answer(288), []string{"matches the expected answer"}},
+		{nil, watchRun{Err: "not implemented"}, answer(405), nil},
+		{&watchRun{Answer: answer(288)}, watchRun{Answer: answer(288)}, nil, []string{"unchanged since the last run"}},
+		{&watchRun{Answer: answer(300)}, watchRun{Answer: answer(288)}, answer(290), []string{"changed from 300", "expected 290, off by -2"}},
+		{&watchRun{Err: "not implemented"}, watchRun{Answer: answer(405)}, answer(405), []string{"fixed since the last run", "matches the expected answer"}},
+	}
+	for _, test := range tests {
+		if got := compareRuns(test.prev, test.run, test.expected); !slices.Equal(got, test.lines) {
+			t.Errorf("compareRuns(%v, %v, %v): got %#v, expected %#v", test.prev, test.run, test.expected, got, test.lines)
+		}
+	}
+}
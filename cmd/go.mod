@@ -0,0 +1,3 @@
+module aoc/cmd
+
+go 1.21.1
@@ -0,0 +1,18 @@
+// Package days imports every day, each of which registers its solver with
+// the solver package when imported.
+package days
+
+import (
+	_ "aoc/day1"
+	_ "aoc/day10"
+	_ "aoc/day11"
+	_ "aoc/day13"
+	_ "aoc/day2"
+	_ "aoc/day3"
+	_ "aoc/day4"
+	_ "aoc/day5"
+	_ "aoc/day6"
+	_ "aoc/day7"
+	_ "aoc/day8"
+	_ "aoc/day9"
+)
@@ -0,0 +1,153 @@
+// Package site talks to the Advent of Code website.
+package site
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL   = "https://adventofcode.com"
+	DefaultUserAgent = "github.com/trolleyman/aoc-2023 aoc tool"
+	// DefaultMinInterval is the default minimum time between requests.
+	DefaultMinInterval = 5 * time.Second
+)
+
+// ErrUnauthorized is returned when the site rejects the session token.
+var ErrUnauthorized = errors.New("session token rejected (log in again and update it)")
+
+// Client makes throttled requests to the site, authenticated with a session token.
+type Client struct {
+	BaseURL    string
+	Session    string
+	UserAgent  string
+	HTTPClient *http.Client
+	// MinInterval is the minimum time between the start of two requests
+	MinInterval time.Duration
+	// StateFile, if set, records the time of the last request so that
+	// throttling also applies across separate runs
+	StateFile string
+
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+// NewClient returns a client for the given base URL, using the default
+// user agent and throttling. The last request time is kept in the user's
+// cache directory if there is one.
+func NewClient(baseURL string, session string) *Client {
+	c := &Client{
+		BaseURL:     strings.TrimSuffix(baseURL, "/"),
+		Session:     session,
+		UserAgent:   DefaultUserAgent,
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+		MinInterval: DefaultMinInterval,
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		c.StateFile = filepath.Join(dir, "aoc", "last-request")
+	}
+	return c
+}
+
+// Input downloads the puzzle input for the given year and day.
+func (c *Client) Input(ctx context.Context, year int, day int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%v/%v/day/%v/input", c.BaseURL, year, day), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return body, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("input for %v day %v is not available yet", year, day)
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized:
+		return nil, ErrUnauthorized
+	}
+	return nil, fmt.Errorf("fetching input for %v day %v: unexpected status %v", year, day, resp.Status)
+}
+
+// Do sends req with the session cookie and user agent set, first waiting
+// until at least MinInterval has passed since the previous request.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if c.Session == "" {
+		return nil, errors.New("no session token set")
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return httpClient.Do(req)
+}
+
+func (c *Client) wait(ctx context.Context) error {
+	last := c.lastRequest
+	if stateLast, ok := c.readState(); ok && stateLast.After(last) {
+		last = stateLast
+	}
+	if delay := time.Until(last.Add(c.MinInterval)); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	c.lastRequest = time.Now()
+	c.writeState(c.lastRequest)
+	return nil
+}
+
+func (c *Client) readState() (time.Time, bool) {
+	if c.StateFile == "" {
+		return time.Time{}, false
+	}
+	data, err := os.ReadFile(c.StateFile)
+	if err != nil {
+		return time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
+
+// writeState records the request time on a best-effort basis: failing to
+// record it only weakens throttling across runs.
+func (c *Client) writeState(t time.Time) {
+	if c.StateFile == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.StateFile), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(c.StateFile, []byte(strconv.FormatInt(t.UnixNano(), 10)), 0o644)
+}
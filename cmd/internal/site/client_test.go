@@ -0,0 +1,115 @@
+package site
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testSession = "abc123"
+
+// newTestServer serves inputs for 2023 day 1, recording how many requests it receives.
+func newTestServer(t *testing.T, requests *int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.UserAgent() != DefaultUserAgent {
+			t.Errorf("got user agent %#v, expected %#v", r.UserAgent(), DefaultUserAgent)
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != testSession {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/2023/day/1/input" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("1abc2\n"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(baseURL string, session string) *Client {
+	c := NewClient(baseURL, session)
+	c.MinInterval = 0
+	c.StateFile = ""
+	return c
+}
+
+func TestInput(t *testing.T) {
+	requests := 0
+	server := newTestServer(t, &requests)
+
+	data, err := newTestClient(server.URL, testSession).Input(context.Background(), 2023, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "1abc2\n" {
+		t.Errorf("got input %#v", string(data))
+	}
+
+	_, err = newTestClient(server.URL, testSession).Input(context.Background(), 2023, 2)
+	if err == nil {
+		t.Error("expected an error for an unavailable day")
+	}
+
+	_, err = newTestClient(server.URL, "wrong").Input(context.Background(), 2023, 1)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("got error %v, expected ErrUnauthorized", err)
+	}
+}
+
+func TestCacheInput(t *testing.T) {
+	requests := 0
+	server := newTestServer(t, &requests)
+	c := newTestClient(server.URL, testSession)
+	path := filepath.Join(t.TempDir(), "day1", "input.txt")
+
+	for i, expectFetched := range []bool{true, false} {
+		fetched, err := c.CacheInput(context.Background(), 2023, 1, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fetched != expectFetched {
+			t.Errorf("call %v: got fetched %v, expected %v", i+1, fetched, expectFetched)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %v requests, expected 1", requests)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "1abc2\n" {
+		t.Errorf("got cached input %#v", string(data))
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	requests := 0
+	server := newTestServer(t, &requests)
+	stateFile := filepath.Join(t.TempDir(), "last-request")
+	const interval = 100 * time.Millisecond
+
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		// A new client per request, so only the state file carries the last request time
+		c := newTestClient(server.URL, testSession)
+		c.MinInterval = interval
+		c.StateFile = stateFile
+		if _, err := c.Input(context.Background(), 2023, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < interval {
+		t.Errorf("two requests took %v, expected at least %v", elapsed, interval)
+	}
+}
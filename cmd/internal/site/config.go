@@ -0,0 +1,58 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SessionEnv is the environment variable holding the session token.
+	SessionEnv = "AOC_SESSION"
+	// BaseURLEnv is the environment variable that overrides DefaultBaseURL.
+	BaseURLEnv = "AOC_BASE_URL"
+)
+
+// SessionFile returns the path of the config file holding the session
+// token, used when SessionEnv is unset.
+func SessionFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "session"), nil
+}
+
+// LoadSession returns the session token from SessionEnv, or failing that
+// from the file at SessionFile.
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SessionEnv)); session != "" {
+		return session, nil
+	}
+	path, err := SessionFile()
+	if err != nil {
+		return "", fmt.Errorf("no session token: %v is unset and %w", SessionEnv, err)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("no session token: set %v or write it to %v", SessionEnv, path)
+	} else if err != nil {
+		return "", err
+	}
+	session := strings.TrimSpace(string(data))
+	if session == "" {
+		return "", fmt.Errorf("no session token: %v is empty", path)
+	}
+	return session, nil
+}
+
+// BaseURL returns the site's base URL, which BaseURLEnv overrides.
+func BaseURL() string {
+	if baseURL := os.Getenv(BaseURLEnv); baseURL != "" {
+		return baseURL
+	}
+	return DefaultBaseURL
+}
@@ -0,0 +1,86 @@
+package site
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HistoryFileName is the name of the file in each day's directory that
+// records every answer submitted for that day.
+const HistoryFileName = "submissions.json"
+
+type Submission struct {
+	// Year is the puzzle year, as each day's directory may be used for several
+	Year    int       `json:"year"`
+	Part    int       `json:"part"`
+	Answer  int       `json:"answer"`
+	Outcome Outcome   `json:"outcome"`
+	Time    time.Time `json:"time"`
+	// Wait is how long the site asked us to wait afterwards, if it said
+	Wait time.Duration `json:"wait,omitempty"`
+}
+
+type History []Submission
+
+// LoadHistory reads the submission history from dir, returning none if dir has no history file.
+func LoadHistory(dir string) (History, error) {
+	data, err := os.ReadFile(filepath.Join(dir, HistoryFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var history History
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("%v: %w", filepath.Join(dir, HistoryFileName), err)
+	}
+	return history, nil
+}
+
+func SaveHistory(dir string, history History) error {
+	data, err := json.MarshalIndent(history, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, HistoryFileName), append(data, '\n'), 0o644)
+}
+
+// Check returns an error if submitting answer for part of the year's puzzle
+// at time now is pointless: the part is already solved, the answer is known to
+// be wrong or outside the bounds given by earlier too high/too low answers, or
+// the site asked us to wait after the last submission for the year.
+func (h History) Check(year int, part int, answer int, now time.Time) error {
+	var last *Submission
+	for i, s := range h {
+		if s.Year != year {
+			continue
+		}
+		last = &h[i]
+		if s.Part != part {
+			continue
+		}
+		switch {
+		case s.Outcome == Correct && s.Answer == answer:
+			return fmt.Errorf("answer %v was already accepted for part %v", answer, part)
+		case s.Outcome == Correct:
+			return fmt.Errorf("part %v was already solved with answer %v, not %v", part, s.Answer, answer)
+		case s.Outcome.IsWrong() && s.Answer == answer:
+			return fmt.Errorf("answer %v was already rejected for part %v (%v)", answer, part, s.Outcome)
+		case s.Outcome == TooHigh && answer > s.Answer:
+			return fmt.Errorf("answer %v is higher than %v, which was too high", answer, s.Answer)
+		case s.Outcome == TooLow && answer < s.Answer:
+			return fmt.Errorf("answer %v is lower than %v, which was too low", answer, s.Answer)
+		}
+	}
+	if last != nil {
+		if retry := last.Time.Add(last.Wait); now.Before(retry) {
+			return fmt.Errorf("the site asked us to wait until %v before submitting again", retry.Format(time.TimeOnly))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is the site's verdict on a submitted answer.
+type Outcome string
+
+const (
+	Correct     Outcome = "correct"
+	Wrong       Outcome = "wrong"
+	TooHigh     Outcome = "too high"
+	TooLow      Outcome = "too low"
+	RateLimited Outcome = "rate limited"
+	// WrongLevel means the part has already been solved, or is not unlocked yet
+	WrongLevel Outcome = "wrong level"
+)
+
+// IsWrong reports whether the outcome means the answer was incorrect.
+func (o Outcome) IsWrong() bool {
+	return o == Wrong || o == TooHigh || o == TooLow
+}
+
+// Verdict is the parsed response to a submission.
+type Verdict struct {
+	Outcome Outcome
+	// Wait is how long the site asks us to wait before submitting again, if it said
+	Wait time.Duration
+	// Message is the text of the response
+	Message string
+}
+
+var (
+	articleRegexp = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegexp     = regexp.MustCompile(`<[^>]*>`)
+	spaceRegexp   = regexp.MustCompile(`\s+`)
+	leftRegexp    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	minutesRegexp = regexp.MustCompile(`wait (one|\d+) minutes? before trying again`)
+)
+
+// Submit posts the answer for the given part of a day's puzzle.
+func (c *Client) Submit(ctx context.Context, year int, day int, part int, answer string) (Verdict, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%v/%v/day/%v/answer", c.BaseURL, year, day), strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := c.Do(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Verdict{}, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ParseVerdict(string(body))
+	case http.StatusBadRequest, http.StatusUnauthorized:
+		return Verdict{}, ErrUnauthorized
+	}
+	return Verdict{}, fmt.Errorf("submitting answer for %v day %v part %v: unexpected status %v", year, day, part, resp.Status)
+}
+
+// ParseVerdict parses the HTML page returned after submitting an answer.
+func ParseVerdict(page string) (Verdict, error) {
+	message := page
+	if match := articleRegexp.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+	message = strings.TrimSpace(spaceRegexp.ReplaceAllString(tagRegexp.ReplaceAllString(message, ""), " "))
+
+	verdict := Verdict{Message: message}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		verdict.Outcome = Correct
+	case strings.Contains(message, "That's not the right answer"):
+		verdict.Outcome = Wrong
+		if strings.Contains(message, "your answer is too high") {
+			verdict.Outcome = TooHigh
+		} else if strings.Contains(message, "your answer is too low") {
+			verdict.Outcome = TooLow
+		}
+		if match := minutesRegexp.FindStringSubmatch(message); match != nil {
+			minutes := 1
+			if match[1] != "one" {
+				minutes, _ = strconv.Atoi(match[1])
+			}
+			verdict.Wait = time.Duration(minutes) * time.Minute
+		}
+	case strings.Contains(message, "You gave an answer too recently"):
+		verdict.Outcome = RateLimited
+		if match := leftRegexp.FindStringSubmatch(message); match != nil {
+			minutes, _ := strconv.Atoi(match[1])
+			seconds, _ := strconv.Atoi(match[2])
+			verdict.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		}
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		verdict.Outcome = WrongLevel
+	default:
+		return Verdict{}, fmt.Errorf("unrecognised response to submission: %#v", message)
+	}
+	return verdict, nil
+}
@@ -0,0 +1,135 @@
+package site
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Responses modelled on the pages the site returns after a submission
+const (
+	rightPage       = `<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer to restoring snow operations.</p></article></main>`
+	tooHighPage     = `<main><article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data.  Please wait one minute before trying again. <a href="/2023/day/1">[Return to Day 1]</a></p></article></main>`
+	tooLowPage      = `<main><article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article></main>`
+	wrongPage       = `<main><article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.</p></article></main>`
+	rateLimitedPage = `<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 4m 35s left to wait. <a href="/2023/day/1">[Return to Day 1]</a></p></article></main>`
+	wrongLevelPage  = `<main><article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article></main>`
+)
+
+func TestSubmit(t *testing.T) {
+	pages := map[string]string{
+		"100": rightPage,
+		"200": tooHighPage,
+		"50":  tooLowPage,
+		"75":  wrongPage,
+		"99":  rateLimitedPage,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2023/day/1/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.PostFormValue("level") != "1" {
+			w.Write([]byte(wrongLevelPage))
+			return
+		}
+		w.Write([]byte(pages[r.PostFormValue("answer")]))
+	}))
+	defer server.Close()
+	c := newTestClient(server.URL, testSession)
+
+	tests := []struct {
+		part    int
+		answer  string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{1, "100", Correct, 0},
+		{1, "200", TooHigh, time.Minute},
+		{1, "50", TooLow, 5 * time.Minute},
+		{1, "75", Wrong, 0},
+		{1, "99", RateLimited, 4*time.Minute + 35*time.Second},
+		{2, "100", WrongLevel, 0},
+	}
+	for _, test := range tests {
+		verdict, err := c.Submit(context.Background(), 2023, 1, test.part, test.answer)
+		if err != nil {
+			t.Errorf("part %v answer %v: %v", test.part, test.answer, err)
+			continue
+		}
+		if verdict.Outcome != test.outcome || verdict.Wait != test.wait {
+			t.Errorf("part %v answer %v: got %v (wait %v), expected %v (wait %v)", test.part, test.answer, verdict.Outcome, verdict.Wait, test.outcome, test.wait)
+		}
+		if strings.Contains(verdict.Message, "<") {
+			t.Errorf("part %v answer %v: message contains markup: %#v", test.part, test.answer, verdict.Message)
+		}
+	}
+}
+
+func TestHistoryCheck(t *testing.T) {
+	start := time.Date(2023, 12, 1, 5, 0, 0, 0, time.UTC)
+	history := History{
+		{Year: 2023, Part: 1, Answer: 200, Outcome: TooHigh, Time: start},
+		{Year: 2023, Part: 1, Answer: 50, Outcome: TooLow, Time: start.Add(time.Minute)},
+		{Year: 2023, Part: 1, Answer: 75, Outcome: Wrong, Time: start.Add(2 * time.Minute)},
+		{Year: 2023, Part: 2, Answer: 10, Outcome: Correct, Time: start.Add(3 * time.Minute)},
+		{Year: 2023, Part: 1, Answer: 80, Outcome: RateLimited, Time: start.Add(4 * time.Minute), Wait: 30 * time.Second},
+		{Year: 2022, Part: 1, Answer: 60, Outcome: Wrong, Time: start.Add(5 * time.Minute), Wait: time.Hour},
+	}
+	later := start.Add(time.Hour)
+
+	tests := []struct {
+		year   int
+		part   int
+		answer int
+		now    time.Time
+		ok     bool
+	}{
+		{2023, 1, 100, later, true},
+		{2023, 1, 80, later, true},
+		{2023, 1, 60, later, true},
+		{2023, 1, 200, later, false},
+		{2023, 1, 201, later, false},
+		{2023, 1, 50, later, false},
+		{2023, 1, 49, later, false},
+		{2023, 1, 75, later, false},
+		{2023, 2, 10, later, false},
+		{2023, 2, 11, later, false},
+		{2023, 1, 100, start.Add(4*time.Minute + 10*time.Second), false},
+		// Submissions for other years neither rule out answers nor make us wait
+		{2022, 1, 200, start.Add(10 * time.Minute), false},
+		{2022, 1, 200, later.Add(10 * time.Minute), true},
+		{2022, 2, 11, later.Add(10 * time.Minute), true},
+		{2022, 1, 60, later.Add(10 * time.Minute), false},
+		{2024, 1, 75, start.Add(5*time.Minute + time.Second), true},
+	}
+	for _, test := range tests {
+		err := history.Check(test.year, test.part, test.answer, test.now)
+		if (err == nil) != test.ok {
+			t.Errorf("%v part %v answer %v at %v: got error %v, expected ok %v", test.year, test.part, test.answer, test.now.Format(time.TimeOnly), err, test.ok)
+		}
+	}
+}
+
+func TestHistoryRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	history, err := LoadHistory(dir)
+	if err != nil || history != nil {
+		t.Fatalf("got %v, %v for a missing history file", history, err)
+	}
+
+	history = History{{Year: 2023, Part: 1, Answer: 42, Outcome: TooLow, Time: time.Date(2023, 12, 1, 5, 0, 0, 0, time.UTC), Wait: time.Minute}}
+	if err := SaveHistory(dir, history); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadHistory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 1 || loaded[0] != history[0] {
+		t.Errorf("got %+v, expected %+v", loaded, history)
+	}
+}
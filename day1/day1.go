@@ -0,0 +1,127 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+var digits = newDigitMatcher(makeDigitsMap(nil))
+
+func init() {
+	solver.Register(solver.Day{Number: 1, Args: []string{"useWords", "vocabulary", "explain"}, New: newSolver})
+}
+
+type daySolver struct {
+	// useWords overrides whether words are matched in both parts, if set
+	useWords *bool
+	// digitsWithWords matches the digits and the words of the vocabulary
+	digitsWithWords *digitMatcher
+	explain         explainMode
+	log             *slog.Logger
+	lines           []input.Line
+}
+
+// newSolver accepts the optional arguments useWords, vocabulary and explain.
+// An empty useWords leaves the default of only matching words in part 2, and
+// vocabulary is a preset or file accepted by loadVocabulary, by default en.
+// explain adds the tokens matched in every line to the details of the answer,
+// or with "disagree" only in lines whose value depends on matching words.
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	if len(opts.Args) > 3 {
+		return nil, fmt.Errorf("invalid number of arguments (expected at most 3, got %v)", len(opts.Args))
+	}
+	s := &daySolver{log: opts.Logger()}
+	if len(opts.Args) >= 1 && opts.Args[0] != "" {
+		useWords, err := strconv.ParseBool(opts.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		s.useWords = &useWords
+	}
+	vocabulary := defaultVocabulary
+	if len(opts.Args) >= 2 && opts.Args[1] != "" {
+		vocabulary = opts.Args[1]
+	}
+	words, err := loadVocabulary(vocabulary, opts.ReadArgFile)
+	if err != nil {
+		return nil, err
+	}
+	s.digitsWithWords = newDigitMatcher(makeDigitsMap(words))
+	if len(opts.Args) >= 3 {
+		s.explain, err = parseExplainMode(opts.Args[2])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return err
+	}
+	s.lines = lines
+	return nil
+}
+
+func (s *daySolver) getDigits(useWords bool) *digitMatcher {
+	if useWords {
+		return s.digitsWithWords
+	}
+	return digits
+}
+
+func getFirstLastDigits(line string, m *digitMatcher) (match, match, error) {
+	first, found := m.first(line)
+	if !found {
+		return match{}, match{}, errors.New("first digit not found")
+	}
+	last, found := m.last(line)
+	if !found {
+		return match{}, match{}, errors.New("last digit not found")
+	}
+	return first, last, nil
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	return s.getCalibrationSum(false)
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	return s.getCalibrationSum(true)
+}
+
+func (s *daySolver) getCalibrationSum(useWords bool) (solver.Answer, error) {
+	if s.useWords != nil {
+		useWords = *s.useWords
+	}
+
+	sum := 0
+	explanations := []lineExplanation{}
+	for _, line := range s.lines {
+		first, last, err := getFirstLastDigits(line.Text, s.getDigits(useWords))
+		if err != nil {
+			return solver.Answer{}, line.Error(err)
+		}
+		lineSum := first.Value*10 + last.Value
+		sum += lineSum
+		s.log.Debug("calibration", "line", line.Number, "first", first.Token, "last", last.Token, "lineSum", lineSum, "sum", sum)
+		if s.explain != explainNone {
+			if e, ok := s.explainLine(line, first, last, useWords); ok {
+				explanations = append(explanations, e)
+			}
+		}
+	}
+	answer := solver.Answer{Value: sum}
+	if s.explain != explainNone {
+		answer.Details = explanations
+	}
+	return answer, nil
+}
@@ -0,0 +1,319 @@
+package day1
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"aoc/solver"
+)
+
+// scanFirstDigit and scanLastDigit are the original digit search, which tries
+// every token at every offset. They are kept to check and benchmark the matcher against.
+func scanFirstDigit(line string, vocabulary map[string]int) (int, bool) {
+	for i := 0; i < len(line); i++ {
+		for token, digit := range vocabulary {
+			if strings.HasPrefix(line[i:], token) {
+				return digit, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func scanLastDigit(line string, vocabulary map[string]int) (int, bool) {
+	for i := len(line) - 1; i >= 0; i-- {
+		for token, digit := range vocabulary {
+			if strings.HasPrefix(line[i:], token) {
+				return digit, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// randomLines returns lines of letters from the digit words, and a few digits,
+// so that words often overlap and almost match.
+func randomLines(rng *rand.Rand, n int) []string {
+	const alphabet = "onetwhrfuivsxg"
+	lines := make([]string, n)
+	for i := range lines {
+		b := make([]byte, 10+rng.Intn(60))
+		for j := range b {
+			if rng.Intn(30) == 0 {
+				b[j] = byte('1' + rng.Intn(9))
+			} else {
+				b[j] = alphabet[rng.Intn(len(alphabet))]
+			}
+		}
+		lines[i] = string(b)
+	}
+	return lines
+}
+
+func TestDigitMatcher(t *testing.T) {
+	digitsWithWords := newDigitMatcher(makeDigitsMap(presets["en"]))
+	tests := []struct {
+		line        string
+		first, last int
+	}{
+		{"two1nine", 2, 9},
+		{"eightwothree", 8, 3},
+		{"xtwone3four", 2, 4},
+		{"zoneight234", 1, 4},
+		{"twone", 2, 1},
+		{"oneight", 1, 8},
+		{"sevenine", 7, 9},
+		{"7pqrstsixteen", 7, 6},
+		{"nineight", 9, 8},
+	}
+	for _, test := range tests {
+		first, found := digitsWithWords.first(test.line)
+		if !found || first.Value != test.first {
+			t.Errorf("first(%#v): got %v, %v, expected %v", test.line, first, found, test.first)
+		}
+		last, found := digitsWithWords.last(test.line)
+		if !found || last.Value != test.last {
+			t.Errorf("last(%#v): got %v, %v, expected %v", test.line, last, found, test.last)
+		}
+	}
+	if _, found := digitsWithWords.first("onx"); found {
+		t.Errorf("found a digit in \"onx\"")
+	}
+	if _, found := digitsWithWords.last(""); found {
+		t.Errorf("found a digit in \"\"")
+	}
+}
+
+func TestDigitMatcherOverlappingTokens(t *testing.T) {
+	// Of tokens starting at the same place, the longest wins
+	m := newDigitMatcher(map[string]int{"ab": 1, "abcd": 2, "bc": 3, "c": 4, "d": 5})
+	tests := []struct {
+		line            string
+		first, last     int
+		firstAt, lastAt int
+	}{
+		{"abcd", 2, 5, 0, 3},
+		{"xabcx", 1, 4, 1, 3},
+		{"xbcdab", 3, 1, 1, 4},
+	}
+	for _, test := range tests {
+		first, _ := m.first(test.line)
+		last, _ := m.last(test.line)
+		if first.Value != test.first || first.Offset != test.firstAt || last.Value != test.last || last.Offset != test.lastAt {
+			t.Errorf("%#v: got first %+v and last %+v, expected first %v at %v and last %v at %v",
+				test.line, first, last, test.first, test.firstAt, test.last, test.lastAt)
+		}
+	}
+}
+
+func TestDigitMatcherMatchesScan(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, words := range [][]word{nil, presets["en"]} {
+		vocabulary := makeDigitsMap(words)
+		m := newDigitMatcher(vocabulary)
+		for _, line := range randomLines(rng, 10000) {
+			expectedFirst, expectedFound := scanFirstDigit(line, vocabulary)
+			if first, found := m.first(line); first.Value != expectedFirst || found != expectedFound {
+				t.Fatalf("first(%#v): got %v, %v, expected %v, %v", line, first, found, expectedFirst, expectedFound)
+			}
+			expectedLast, expectedFound := scanLastDigit(line, vocabulary)
+			if last, found := m.last(line); last.Value != expectedLast || found != expectedFound {
+				t.Fatalf("last(%#v): got %v, %v, expected %v, %v", line, last, found, expectedLast, expectedFound)
+			}
+		}
+	}
+}
+
+func TestPresetVocabularies(t *testing.T) {
+	tests := []struct {
+		vocabulary string
+		line       string
+		first      int
+		last       int
+	}{
+		{"en", "xtwone3four", 2, 4},
+		{"de", "achtzweifünfx", 8, 5},
+		{"fr", "septrois2huitx", 7, 8},
+		{"roman", "xVIIyIVz", 7, 5},
+		{"roman", "VIII", 8, 1},
+	}
+	for _, test := range tests {
+		s, err := newSolver(solver.Options{Args: []string{"true", test.vocabulary}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, last, err := getFirstLastDigits(test.line, s.(*daySolver).digitsWithWords)
+		if err != nil || first.Value != test.first || last.Value != test.last {
+			t.Errorf("%v %#v: got %+v, %+v (%v), expected %v, %v", test.vocabulary, test.line, first, last, err, test.first, test.last)
+		}
+	}
+	for name, words := range presets {
+		if err := validateVocabulary(words); err != nil {
+			t.Errorf("preset %v: %v", name, err)
+		}
+	}
+}
+
+func TestLoadVocabulary(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"es.json":        `{"es": {"uno": 1, "dos": 2, "cero": 0}}`,
+		"many.toml":      "# numbers\n[es]\nuno = 1\n\"dos\" = 2 # two\n\n[\"it\"]\nuno = 1\ndue = 2\n",
+		"twice.json":     `{"x": {"one": 1, "one": 2}}`,
+		"empty.toml":     "[x]\n\"\" = 1\n",
+		"digit.json":     `{"x": {"7": 8}}`,
+		"negative.toml":  "[x]\nminus = -1\n",
+		"badvalue.toml":  "[x]\nuno = one\n",
+		"bare.toml":      "[x]\nfünf = 5\n",
+		"notable.toml":   "uno = 1\n",
+		"notobject.json": `{"x": [1, 2]}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	valid := map[string][]word{
+		path("es.json"):      {{"uno", 1}, {"dos", 2}, {"cero", 0}},
+		path("many.toml#es"): {{"uno", 1}, {"dos", 2}},
+		path("many.toml#it"): {{"uno", 1}, {"due", 2}},
+		"roman":              presets["roman"],
+	}
+	for spec, expected := range valid {
+		words, err := loadVocabulary(spec, os.ReadFile)
+		if err != nil {
+			t.Errorf("%v: %v", spec, err)
+		} else if !slices.Equal(words, expected) {
+			t.Errorf("%v: got %v, expected %v", spec, words, expected)
+		}
+	}
+
+	invalid := map[string]string{
+		"klingon":              "unknown vocabulary",
+		path("many.toml"):      "has 2 vocabularies (es, it)",
+		path("many.toml#fr"):   "has no vocabulary \"fr\"",
+		path("missing.json"):   "no such file",
+		path("twice.json"):     "token \"one\" defined twice",
+		path("empty.toml"):     "empty token",
+		path("digit.json"):     "already the digit 7",
+		path("negative.toml"):  "negative value",
+		path("badvalue.toml"):  "badvalue.toml:2:7: invalid number",
+		path("bare.toml"):      "bare.toml:2:2: invalid character 'ü'",
+		path("notable.toml"):   "expected a [vocabulary] table",
+		path("notobject.json"): "not an object",
+	}
+	for spec, expected := range invalid {
+		_, err := loadVocabulary(spec, os.ReadFile)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("%v: got error %v, expected %#v", spec, err, expected)
+		}
+	}
+
+	// Without a way to read files, only presets can be used
+	if _, err := newSolver(solver.Options{Args: []string{"", "roman"}}); err != nil {
+		t.Errorf("roman without files: %v", err)
+	}
+	if _, err := newSolver(solver.Options{Args: []string{"", path("es.json")}}); !errors.Is(err, solver.ErrNoFiles) {
+		t.Errorf("file without files: got error %v, expected %v", err, solver.ErrNoFiles)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	const text = "two1nine\n1abc2\n7pqrstsixteen\n"
+	s, err := newSolver(solver.Options{Args: []string{"", "", "disagree"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Parse(strings.NewReader(text)); err != nil {
+		t.Fatal(err)
+	}
+	answer, err := s.Part2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	explanations := answer.Details.([]lineExplanation)
+	if len(explanations) != 2 || explanations[0].Line != 1 || explanations[1].Line != 3 {
+		t.Fatalf("got %+v, expected lines 1 and 3, which disagree", explanations)
+	}
+	e := explanations[1]
+	expected := lineExplanation{Line: 3, Text: "7pqrstsixteen", First: match{"7", 0, 7}, Last: match{"six", 6, 6}, Value: 76, Disagree: true}
+	if e.OtherValue == nil || *e.OtherValue != 77 {
+		t.Errorf("got other value %v, expected 77", e.OtherValue)
+	}
+	e.OtherValue = nil
+	if e != expected {
+		t.Errorf("got %+v, expected %+v", e, expected)
+	}
+
+	answer, err = s.Part1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if explanations := answer.Details.([]lineExplanation); len(explanations) != 2 || explanations[0].Value != 11 {
+		t.Errorf("got %+v, expected lines 1 and 3 matching only digits", explanations)
+	}
+}
+
+// benchmarkLines returns about 4MB of lines to search, generated on first use
+// so that only benchmarks pay for them
+var benchmarkLines = sync.OnceValue(func() []string {
+	return randomLines(rand.New(rand.NewSource(2)), 100000)
+})
+
+func benchmarkBytes(lines []string) int64 {
+	n := 0
+	for _, line := range lines {
+		n += len(line) + 1
+	}
+	return int64(n)
+}
+
+func BenchmarkDigitMatcher(b *testing.B) {
+	for _, words := range [][]word{nil, presets["en"]} {
+		m := newDigitMatcher(makeDigitsMap(words))
+		name := "digits"
+		if words != nil {
+			name = "words"
+		}
+		b.Run(name, func(b *testing.B) {
+			lines := benchmarkLines()
+			b.SetBytes(benchmarkBytes(lines))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, line := range lines {
+					m.first(line)
+					m.last(line)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDigitScan(b *testing.B) {
+	for _, words := range [][]word{nil, presets["en"]} {
+		vocabulary := makeDigitsMap(words)
+		name := "digits"
+		if words != nil {
+			name = "words"
+		}
+		b.Run(name, func(b *testing.B) {
+			lines := benchmarkLines()
+			b.SetBytes(benchmarkBytes(lines))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, line := range lines {
+					scanFirstDigit(line, vocabulary)
+					scanLastDigit(line, vocabulary)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package day1
+
+import (
+	"fmt"
+
+	"aoc/input"
+)
+
+// explainMode selects the lines explained in the details of the answer.
+type explainMode int
+
+const (
+	explainNone explainMode = iota
+	explainAll
+	// explainDisagreements only explains lines whose value matching words
+	// differs from their value matching only digits
+	explainDisagreements
+)
+
+func parseExplainMode(s string) (explainMode, error) {
+	switch s {
+	case "", "false":
+		return explainNone, nil
+	case "true", "all":
+		return explainAll, nil
+	case "disagree":
+		return explainDisagreements, nil
+	}
+	return explainNone, fmt.Errorf("invalid explain mode %#v. Expected all/disagree/false", s)
+}
+
+// lineExplanation shows how the calibration value of a line was found.
+type lineExplanation struct {
+	Line  int    `json:"line"`
+	Text  string `json:"text"`
+	First match  `json:"first"`
+	Last  match  `json:"last"`
+	Value int    `json:"value"`
+	// Disagree is set if matching words and matching only digits give the line different values
+	Disagree bool `json:"disagree,omitempty"`
+	// OtherValue is the value given by the other way of matching, if it disagrees and finds any digits
+	OtherValue *int `json:"otherValue,omitempty"`
+}
+
+// explainLine explains the value of a line, found with or without words, or
+// returns false if the mode leaves it out.
+func (s *daySolver) explainLine(line input.Line, first match, last match, useWords bool) (lineExplanation, bool) {
+	e := lineExplanation{Line: line.Number, Text: line.Text, First: first, Last: last, Value: first.Value*10 + last.Value}
+	otherFirst, otherLast, err := getFirstLastDigits(line.Text, s.getDigits(!useWords))
+	if err != nil {
+		e.Disagree = true
+	} else if otherValue := otherFirst.Value*10 + otherLast.Value; otherValue != e.Value {
+		e.Disagree = true
+		e.OtherValue = &otherValue
+	}
+	if s.explain == explainDisagreements && !e.Disagree {
+		return lineExplanation{}, false
+	}
+	return e, true
+}
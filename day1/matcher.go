@@ -0,0 +1,145 @@
+package day1
+
+import (
+	"slices"
+)
+
+// automaton is an Aho-Corasick automaton over bytes, recognising every token
+// of a vocabulary that ends at each position of the text fed to it.
+type automaton struct {
+	// next is the state reached from each state on each byte, with failure
+	// links already followed, so that every byte costs one lookup
+	next [][256]int32
+	// longest is the index of the longest token ending in each state, or -1
+	longest []int32
+}
+
+func newAutomaton(tokens []string) *automaton {
+	a := &automaton{next: make([][256]int32, 1), longest: []int32{-1}}
+	// Build the trie of tokens, with 0 for missing edges, as the root is never a child
+	for i, token := range tokens {
+		state := int32(0)
+		for j := 0; j < len(token); j++ {
+			if a.next[state][token[j]] == 0 {
+				a.next = append(a.next, [256]int32{})
+				a.longest = append(a.longest, -1)
+				a.next[state][token[j]] = int32(len(a.next) - 1)
+			}
+			state = a.next[state][token[j]]
+		}
+		if a.longest[state] < 0 || len(tokens[a.longest[state]]) < len(token) {
+			a.longest[state] = int32(i)
+		}
+	}
+
+	// Fill in the failure transitions breadth first, so that a state's failure
+	// state is always complete before its children need it
+	fail := make([]int32, len(a.next))
+	var queue []int32
+	for c := 0; c < 256; c++ {
+		if child := a.next[0][c]; child != 0 {
+			queue = append(queue, child)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		// A token ending in the failure state is shorter than one ending here, as it's a proper suffix
+		if a.longest[state] < 0 {
+			a.longest[state] = a.longest[fail[state]]
+		}
+		for c := 0; c < 256; c++ {
+			child := a.next[state][c]
+			if child == 0 {
+				a.next[state][c] = a.next[fail[state]][c]
+				continue
+			}
+			fail[child] = a.next[fail[state]][c]
+			queue = append(queue, child)
+		}
+	}
+	return a
+}
+
+// digitMatcher finds the first and last digit tokens in a line in one pass each.
+//
+// If tokens overlap, the first token is the one that starts first, and the last
+// token is the one that starts last, e.g. "twone" starts with two and ends with
+// one. Of tokens starting at the same place, the longest wins.
+type digitMatcher struct {
+	tokens []string
+	values []int
+	maxLen int
+	// forward matches the tokens, and backward matches them reversed in the line read backwards
+	forward  *automaton
+	backward *automaton
+}
+
+func newDigitMatcher(vocabulary map[string]int) *digitMatcher {
+	m := &digitMatcher{}
+	for token := range vocabulary {
+		m.tokens = append(m.tokens, token)
+	}
+	slices.Sort(m.tokens)
+	reversed := make([]string, len(m.tokens))
+	for i, token := range m.tokens {
+		m.values = append(m.values, vocabulary[token])
+		m.maxLen = max(m.maxLen, len(token))
+		reversed[i] = reverse(token)
+	}
+	m.forward = newAutomaton(m.tokens)
+	m.backward = newAutomaton(reversed)
+	return m
+}
+
+func reverse(s string) string {
+	b := []byte(s)
+	slices.Reverse(b)
+	return string(b)
+}
+
+// match is an occurrence of a token in a line.
+type match struct {
+	Token string `json:"token"`
+	// Offset is the byte offset of the start of the token in the line
+	Offset int `json:"offset"`
+	Value  int `json:"value"`
+}
+
+// first returns the first token in line.
+func (m *digitMatcher) first(line string) (match, bool) {
+	best := int32(-1)
+	bestStart := len(line)
+	state := int32(0)
+	for i := 0; i < len(line); i++ {
+		// No token ending from here on can start at or before the best so far
+		if i+1-m.maxLen > bestStart {
+			break
+		}
+		state = m.forward.next[state][line[i]]
+		// The longest token ending here is the one that starts earliest, and
+		// ending later it is longer than the best so far if it starts at the same place
+		if token := m.forward.longest[state]; token >= 0 {
+			if start := i + 1 - len(m.tokens[token]); start <= bestStart {
+				best, bestStart = token, start
+			}
+		}
+	}
+	if best < 0 {
+		return match{}, false
+	}
+	return match{Token: m.tokens[best], Offset: bestStart, Value: m.values[best]}, true
+}
+
+// last returns the token in line that starts last.
+func (m *digitMatcher) last(line string) (match, bool) {
+	state := int32(0)
+	for i := len(line) - 1; i >= 0; i-- {
+		state = m.backward.next[state][line[i]]
+		// Reading backwards, the first token found is the one that starts last
+		if token := m.backward.longest[state]; token >= 0 {
+			return match{Token: m.tokens[token], Offset: i, Value: m.values[token]}, true
+		}
+	}
+	return match{}, false
+}
@@ -0,0 +1,268 @@
+package day1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aoc/input"
+)
+
+// defaultVocabulary is the preset the words of part 2 come from, unless another is given
+const defaultVocabulary = "en"
+
+// word is a token in a vocabulary that spells out a digit.
+type word struct {
+	Token string
+	Value int
+}
+
+// presets are the built-in vocabularies, selected by name.
+var presets = map[string][]word{
+	"en": {
+		{"one", 1}, {"two", 2}, {"three", 3}, {"four", 4}, {"five", 5},
+		{"six", 6}, {"seven", 7}, {"eight", 8}, {"nine", 9},
+	},
+	"de": {
+		{"eins", 1}, {"zwei", 2}, {"drei", 3}, {"vier", 4}, {"fünf", 5},
+		{"sechs", 6}, {"sieben", 7}, {"acht", 8}, {"neun", 9},
+	},
+	"fr": {
+		{"un", 1}, {"deux", 2}, {"trois", 3}, {"quatre", 4}, {"cinq", 5},
+		{"six", 6}, {"sept", 7}, {"huit", 8}, {"neuf", 9},
+	},
+	"roman": {
+		{"I", 1}, {"II", 2}, {"III", 3}, {"IV", 4}, {"V", 5},
+		{"VI", 6}, {"VII", 7}, {"VIII", 8}, {"IX", 9},
+	},
+}
+
+func presetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// makeDigitsMap returns the tokens matched as digits: the digits 1 to 9, and
+// the words of the vocabulary if there is one. The words must be valid.
+func makeDigitsMap(words []word) map[string]int {
+	m := make(map[string]int)
+	for _, w := range words {
+		m[w.Token] = w.Value
+	}
+	for i := 1; i < 10; i++ {
+		m[strconv.Itoa(i)] = i
+	}
+	return m
+}
+
+// validateVocabulary checks that every token is non-empty, is only defined
+// once, and doesn't give a digit a different value.
+func validateVocabulary(words []word) error {
+	values := make(map[string]int)
+	for _, w := range words {
+		if w.Token == "" {
+			return fmt.Errorf("empty token for %v", w.Value)
+		}
+		if w.Value < 0 {
+			return fmt.Errorf("negative value %v for %#v", w.Value, w.Token)
+		}
+		if value, found := values[w.Token]; found {
+			return fmt.Errorf("token %#v defined twice, as %v and %v", w.Token, value, w.Value)
+		}
+		if digit, err := strconv.Atoi(w.Token); err == nil && len(w.Token) == 1 && digit >= 1 && digit != w.Value {
+			return fmt.Errorf("token %#v is already the digit %v, not %v", w.Token, digit, w.Value)
+		}
+		values[w.Token] = w.Value
+	}
+	return nil
+}
+
+// loadVocabulary returns a preset vocabulary by name, or one from a .json or
+// .toml file read with readFile, given as "path" if the file has one
+// vocabulary, or "path#name".
+func loadVocabulary(spec string, readFile func(name string) ([]byte, error)) ([]word, error) {
+	path, name, _ := strings.Cut(spec, "#")
+	ext := filepath.Ext(path)
+	if ext != ".json" && ext != ".toml" {
+		words, found := presets[spec]
+		if !found {
+			return nil, fmt.Errorf("unknown vocabulary %#v. Expected one of %v, or a .json or .toml file", spec, strings.Join(presetNames(), "/"))
+		}
+		return words, nil
+	}
+
+	data, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vocabularies map[string][]word
+	if ext == ".json" {
+		vocabularies, err = parseJSONVocabularies(data)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", path, err)
+		}
+	} else {
+		vocabularies, err = parseTOMLVocabularies(data)
+		if err != nil {
+			input.SetFile(err, path)
+			return nil, err
+		}
+	}
+
+	if name == "" {
+		if len(vocabularies) != 1 {
+			names := make([]string, 0, len(vocabularies))
+			for name := range vocabularies {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+			return nil, fmt.Errorf("%v has %v vocabularies (%v). Select one with %v#<name>", path, len(vocabularies), strings.Join(names, ", "), path)
+		}
+		for n := range vocabularies {
+			name = n
+		}
+	}
+	words, found := vocabularies[name]
+	if !found {
+		return nil, fmt.Errorf("%v has no vocabulary %#v", path, name)
+	}
+	if err := validateVocabulary(words); err != nil {
+		return nil, fmt.Errorf("%v#%v: %w", path, name, err)
+	}
+	return words, nil
+}
+
+// parseJSONVocabularies parses an object of vocabularies by name, each an
+// object mapping tokens to values, e.g. {"es": {"uno": 1, "dos": 2}}. Unlike
+// json.Unmarshal, it keeps tokens given twice so that they are reported.
+func parseJSONVocabularies(data []byte) (map[string][]word, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	vocabularies := make(map[string][]word)
+	for name, object := range raw {
+		d := json.NewDecoder(bytes.NewReader(object))
+		if t, err := d.Token(); err != nil || t != json.Delim('{') {
+			return nil, fmt.Errorf("vocabulary %#v is not an object of tokens and values", name)
+		}
+		words := []word{}
+		for d.More() {
+			t, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			token := t.(string)
+			var value int
+			if err := d.Decode(&value); err != nil {
+				return nil, fmt.Errorf("vocabulary %#v: invalid value for %#v: %w", name, token, err)
+			}
+			words = append(words, word{token, value})
+		}
+		vocabularies[name] = words
+	}
+	return vocabularies, nil
+}
+
+// parseTOMLVocabularies parses the subset of TOML needed for vocabularies: a
+// table for each vocabulary, with a key for each token and integer values, e.g.
+//
+//	[es]
+//	uno = 1
+//	"dos" = 2 # comment
+func parseTOMLVocabularies(data []byte) (map[string][]word, error) {
+	lines, err := input.Lines(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	vocabularies := make(map[string][]word)
+	name, inTable := "", false
+	for _, line := range lines {
+		if strings.HasPrefix(line.Text, "#") {
+			continue
+		}
+		if strings.HasPrefix(line.Text, "[") {
+			rest, found := strings.CutSuffix(stripComment(line.Text), "]")
+			if !found {
+				return nil, line.Errorf("expected \"]\"")
+			}
+			name, err = tomlKey(line.Slice(1, len(rest)))
+			if err != nil {
+				return nil, err
+			}
+			if _, found := vocabularies[name]; found {
+				return nil, line.Errorf("table %#v defined twice", name)
+			}
+			vocabularies[name] = []word{}
+			inTable = true
+			continue
+		}
+		if !inTable {
+			return nil, line.Errorf("expected a [vocabulary] table before any tokens")
+		}
+		key, value, found := line.Cut("=")
+		if !found {
+			return nil, line.Errorf("expected token = value")
+		}
+		token, err := tomlKey(key)
+		if err != nil {
+			return nil, err
+		}
+		value = value.Slice(0, len(stripComment(value.Text))).TrimSpace()
+		number, err := value.Atoi()
+		if err != nil {
+			return nil, err
+		}
+		vocabularies[name] = append(vocabularies[name], word{token, number})
+	}
+	return vocabularies, nil
+}
+
+// stripComment removes a trailing # comment outside of quotes.
+func stripComment(s string) string {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case '#':
+			if !quoted {
+				return strings.TrimRightFunc(s[:i], func(r rune) bool { return r == ' ' || r == '\t' })
+			}
+		}
+	}
+	return s
+}
+
+// tomlKey parses a bare or double-quoted TOML key.
+func tomlKey(l input.Line) (string, error) {
+	l = l.TrimSpace()
+	if strings.HasPrefix(l.Text, "\"") {
+		key, err := strconv.Unquote(l.Text)
+		if err != nil {
+			return "", l.Errorf("invalid quoted key %v", l.Text)
+		}
+		return key, nil
+	}
+	if l.Text == "" {
+		return "", l.Errorf("expected a key")
+	}
+	for i, r := range l.Text {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return "", l.Slice(i, len(l.Text)).Errorf("invalid character %q in bare key. Quote keys with other characters", r)
+		}
+	}
+	return l.Text, nil
+}
@@ -0,0 +1,266 @@
+package day10
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"aoc/grid"
+	"aoc/input"
+	"aoc/solver"
+)
+
+type Tile struct {
+	Rune       rune
+	Position   grid.Vec2
+	IsPipe     bool
+	Connecting [4]bool
+}
+
+func (t Tile) isConnecting(d grid.Direction) bool {
+	return t.Connecting[d]
+}
+
+func (t Tile) getTile(pm *PipeMaze, d grid.Direction) *Tile {
+	return pm.getTile(t.Position.Add(d.Offset()))
+}
+
+func (t *Tile) String() string {
+	return fmt.Sprintf("%c", t.Rune)
+}
+
+type PipeMaze struct {
+	Start grid.Vec2
+	Tiles grid.Grid[*Tile]
+}
+
+func (pm *PipeMaze) getTile(position grid.Vec2) *Tile {
+	tile, _ := pm.Tiles.Lookup(position)
+	return tile
+}
+
+func isConnectingIgnoring(tile *Tile, direction grid.Direction, ignoring func(*Tile) bool) bool {
+	if tile == nil {
+		return false
+	}
+	if ignoring(tile) {
+		return false
+	}
+	return tile.isConnecting(direction)
+}
+
+func (pm *PipeMaze) isIntersectionFree(intersection grid.Vec2, direction grid.Direction, ignoring func(*Tile) bool) bool {
+	newIntersection := intersection.Add(direction.Offset())
+	if newIntersection.X < 0 || newIntersection.Y < 0 || newIntersection.X > pm.Tiles.Width || newIntersection.Y > pm.Tiles.Height {
+		return false
+	}
+	nw := pm.getTile(grid.Vec2{X: intersection.X - 1, Y: intersection.Y - 1})
+	ne := pm.getTile(grid.Vec2{X: intersection.X, Y: intersection.Y - 1})
+	sw := pm.getTile(grid.Vec2{X: intersection.X - 1, Y: intersection.Y})
+	se := pm.getTile(grid.Vec2{X: intersection.X, Y: intersection.Y})
+	switch direction {
+	case grid.North:
+		return !isConnectingIgnoring(nw, grid.East, ignoring) && !isConnectingIgnoring(ne, grid.West, ignoring)
+	case grid.East:
+		return !isConnectingIgnoring(ne, grid.South, ignoring) && !isConnectingIgnoring(se, grid.North, ignoring)
+	case grid.South:
+		return !isConnectingIgnoring(sw, grid.East, ignoring) && !isConnectingIgnoring(se, grid.West, ignoring)
+	case grid.West:
+		return !isConnectingIgnoring(nw, grid.South, ignoring) && !isConnectingIgnoring(sw, grid.North, ignoring)
+	}
+	panic("invalid direction")
+}
+
+func (pm *PipeMaze) String() string {
+	return pm.Tiles.String()
+}
+
+func parseInput(r io.Reader) (PipeMaze, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return PipeMaze{}, err
+	}
+
+	var start *Tile
+	tiles, err := grid.Parse(lines, func(position grid.Vec2, r rune) (*Tile, error) {
+		tile := &Tile{Rune: r, Position: position, IsPipe: true}
+		switch r {
+		case '|': // is a vertical pipe connecting north and south.
+			tile.Connecting[grid.North] = true
+			tile.Connecting[grid.South] = true
+		case '-': // is a horizontal pipe connecting east and west.
+			tile.Connecting[grid.East] = true
+			tile.Connecting[grid.West] = true
+		case 'L': // is a 90-degree bend connecting north and east.
+			tile.Connecting[grid.North] = true
+			tile.Connecting[grid.East] = true
+		case 'J': // is a 90-degree bend connecting north and west.
+			tile.Connecting[grid.North] = true
+			tile.Connecting[grid.West] = true
+		case '7': // is a 90-degree bend connecting south and west.
+			tile.Connecting[grid.South] = true
+			tile.Connecting[grid.West] = true
+		case 'F': // is a 90-degree bend connecting south and east.
+			tile.Connecting[grid.East] = true
+			tile.Connecting[grid.South] = true
+		case '.': // is ground; there is no pipe in this tile.
+			tile.IsPipe = false
+		case 'S': // is the starting position of the animal; there is a pipe on this tile, but your sketch doesn't show what shape the pipe has.
+			if start != nil {
+				return nil, fmt.Errorf("second start tile (first is at %v)", start.Position)
+			}
+			start = tile
+		default:
+			return nil, fmt.Errorf("invalid rune %q", r)
+		}
+		return tile, nil
+	})
+	if err != nil {
+		return PipeMaze{}, err
+	}
+
+	if start == nil {
+		return PipeMaze{}, errors.New("no start tile 'S'")
+	}
+
+	pipeMaze := PipeMaze{Start: start.Position, Tiles: tiles}
+
+	// Fixup start connecting
+	for _, direction := range grid.Directions {
+		t := start.getTile(&pipeMaze, direction)
+		if t != nil {
+			// fmt.Printf("t @ %v (conn=%v) (d=%v di=%v)\n", t.Position, t.Connecting, direction, direction.Invert())
+			if t.isConnecting(direction.Invert()) {
+				start.Connecting[direction] = true
+			}
+		}
+	}
+	return pipeMaze, nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 10, New: newSolver, Generate: generate})
+}
+
+// LoopStats holds the intermediate results of part 2.
+type LoopStats struct {
+	MaxStep     int `json:"maxStep"`
+	InsideCount int `json:"insideCount"`
+}
+
+type daySolver struct {
+	log      *slog.Logger
+	pipeMaze PipeMaze
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{log: opts.Logger()}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	pipeMaze, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	solver.DebugLines(s.log, "maze", pipeMaze.String)
+	s.pipeMaze = pipeMaze
+	return nil
+}
+
+// getLoop returns the number of steps from the start to each tile of the loop
+// (-1 for tiles not on the loop), along with the furthest number of steps.
+func (s *daySolver) getLoop() (grid.Grid[int], int) {
+	pipeMaze := s.pipeMaze
+	intMaze := pipeMaze.Tiles.FloodFill([]grid.Vec2{pipeMaze.Start}, func(from grid.Vec2, d grid.Direction) bool {
+		return pipeMaze.getTile(from).isConnecting(d)
+	})
+	step := 0
+	for _, row := range intMaze.Rows() {
+		step = max(step, slices.Max(row))
+	}
+
+	solver.DebugLines(s.log, "loop", func() string {
+		var sb strings.Builder
+		for y := 0; y < intMaze.Height; y++ {
+			if y != 0 {
+				sb.WriteString("\n")
+			}
+			for x := 0; x < intMaze.Width; x++ {
+				val := intMaze.Get(grid.Vec2{X: x, Y: y})
+				if val < 0 {
+					sb.WriteString(pipeMaze.getTile(grid.Vec2{X: x, Y: y}).String())
+				} else if val == 0 {
+					sb.WriteString("S")
+				} else if val == step {
+					sb.WriteString("X")
+				} else {
+					fmt.Fprintf(&sb, "%v", val%10)
+				}
+			}
+		}
+		return sb.String()
+	})
+	return intMaze, step
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	_, step := s.getLoop()
+	return solver.Answer{Value: step}, nil
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	pipeMaze := s.pipeMaze
+	intMaze, step := s.getLoop()
+
+	// Now get all the tiles enclosed by the loop, by flood filling the intersections between tiles from the edges
+	intersections := grid.New[bool](pipeMaze.Tiles.Width+1, pipeMaze.Tiles.Height+1)
+	var edgeIntersections []grid.Vec2
+	for y := 0; y < intersections.Height; y++ {
+		for x := 0; x < intersections.Width; x++ {
+			if x == 0 || y == 0 || x == intersections.Width-1 || y == intersections.Height-1 {
+				edgeIntersections = append(edgeIntersections, grid.Vec2{X: x, Y: y})
+			}
+		}
+	}
+	isNotInLoop := func(t *Tile) bool { return intMaze.Get(t.Position) < 0 }
+	outsideSteps := intersections.FloodFill(edgeIntersections, func(intersection grid.Vec2, direction grid.Direction) bool {
+		return pipeMaze.isIntersectionFree(intersection, direction, isNotInLoop)
+	})
+	isIntersectionOutside := func(x, y int) bool { return outsideSteps.Get(grid.Vec2{X: x, Y: y}) >= 0 }
+
+	solver.DebugLines(s.log, "outside intersections", func() string {
+		return outsideSteps.Format(func(steps int) string {
+			if steps >= 0 {
+				return "-"
+			}
+			return "@"
+		})
+	})
+
+	debug := solver.DebugEnabled(s.log)
+	insideCount := 0
+	var enclosed strings.Builder
+	for y, tileRow := range pipeMaze.Tiles.Rows() {
+		if debug && y != 0 {
+			enclosed.WriteString("\n")
+		}
+		for x, tile := range tileRow {
+			inLoop := tile.IsPipe && intMaze.Get(tile.Position) >= 0
+			inside := !inLoop && !(isIntersectionOutside(x, y) && isIntersectionOutside(x, y+1) && isIntersectionOutside(x+1, y) && isIntersectionOutside(x+1, y+1))
+			if inside {
+				insideCount++
+			}
+			if debug && inside {
+				enclosed.WriteString("I")
+			} else if debug {
+				enclosed.WriteString(tile.String())
+			}
+		}
+	}
+	solver.DebugLines(s.log, "enclosed", enclosed.String)
+
+	return solver.Answer{Value: insideCount, Details: LoopStats{MaxStep: step, InsideCount: insideCount}}, nil
+}
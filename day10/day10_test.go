@@ -0,0 +1,15 @@
+package day10
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzParseInput(f *testing.F) {
+	f.Add(".....\n.S-7.\n.|.|.\n.L-J.\n.....\n")
+	f.Add("-L|F7\n7S-7|\nL|7||\n-L-J|\nL|-JF\n")
+	f.Add("S\n")
+	f.Fuzz(func(t *testing.T, text string) {
+		parseInput(strings.NewReader(text))
+	})
+}
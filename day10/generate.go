@@ -0,0 +1,167 @@
+package day10
+
+import (
+	"math/rand"
+
+	"aoc/grid"
+)
+
+// pipeRunes maps the directions a pipe connects to its rune
+var pipeRunes = map[[4]bool]rune{
+	{grid.North: true, grid.South: true}: '|',
+	{grid.East: true, grid.West: true}:   '-',
+	{grid.North: true, grid.East: true}:  'L',
+	{grid.North: true, grid.West: true}:  'J',
+	{grid.South: true, grid.West: true}:  '7',
+	{grid.East: true, grid.South: true}:  'F',
+}
+
+// generate returns a random maze of about size by size tiles (140 by default).
+// The loop is the outline of a random blob of cells at half the resolution, so
+// that it encloses tiles, and the tiles off the loop are random junk.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 {
+		size = 140
+	}
+	blob := generateBlob(rng, max(1, (size-1)/2))
+
+	// Blob corner (x, y) is tile (2x, 2y), so each side of a blob cell is 3 tiles of the loop
+	tiles := grid.New[*Tile](blob.Width*2+1, blob.Height*2+1)
+	for y := 0; y < tiles.Height; y++ {
+		for x := 0; x < tiles.Width; x++ {
+			p := grid.Vec2{X: x, Y: y}
+			tiles.Set(p, &Tile{Rune: '.', Position: p})
+		}
+	}
+	connect := func(from grid.Vec2, d grid.Direction) {
+		for i := 0; i < 2; i++ {
+			to := from.Add(d.Offset())
+			tiles.Get(from).Connecting[d] = true
+			tiles.Get(to).Connecting[d.Invert()] = true
+			from = to
+		}
+	}
+	for y := 0; y < blob.Height; y++ {
+		for x := 0; x < blob.Width; x++ {
+			if !blob.Get(grid.Vec2{X: x, Y: y}) {
+				continue
+			}
+			corner := grid.Vec2{X: 2 * x, Y: 2 * y}
+			for _, d := range grid.Directions {
+				if inBlob, _ := blob.Lookup(grid.Vec2{X: x, Y: y}.Add(d.Offset())); inBlob {
+					continue
+				}
+				switch d {
+				case grid.North:
+					connect(corner, grid.East)
+				case grid.East:
+					connect(corner.Add(grid.Vec2{X: 2}), grid.South)
+				case grid.South:
+					connect(corner.Add(grid.Vec2{Y: 2}), grid.East)
+				case grid.West:
+					connect(corner, grid.South)
+				}
+			}
+		}
+	}
+	var loop []grid.Vec2
+	for y := 0; y < tiles.Height; y++ {
+		for x := 0; x < tiles.Width; x++ {
+			tile := tiles.Get(grid.Vec2{X: x, Y: y})
+			if r, found := pipeRunes[tile.Connecting]; found {
+				tile.Rune = r
+				tile.IsPipe = true
+				loop = append(loop, tile.Position)
+			}
+		}
+	}
+
+	start := tiles.Get(loop[rng.Intn(len(loop))])
+	start.Rune = 'S'
+	for _, row := range tiles.Rows() {
+		for _, tile := range row {
+			if tile.IsPipe || tile.Position.ManhattanDistance(start.Position) == 1 {
+				continue
+			}
+			// Junk pipes, leaving ground next to the start so that its shape is unambiguous
+			directions := rng.Perm(5)
+			if directions[0] == 4 || directions[1] == 4 {
+				continue
+			}
+			tile.Connecting[directions[0]] = true
+			tile.Connecting[directions[1]] = true
+			tile.Rune = pipeRunes[tile.Connecting]
+			tile.IsPipe = true
+		}
+	}
+	pipeMaze := PipeMaze{Start: start.Position, Tiles: tiles}
+	return pipeMaze.String() + "\n"
+}
+
+// generateBlob grows a random blob of cells in a size by size grid, without
+// holes or cells that only touch diagonally, so that its outline is a single loop.
+func generateBlob(rng *rand.Rand, size int) grid.Grid[bool] {
+	blob := grid.New[bool](size, size)
+	cells := []grid.Vec2{{X: rng.Intn(size), Y: rng.Intn(size)}}
+	blob.Set(cells[0], true)
+	target := max(1, size*size/2)
+	for attempts := 0; len(cells) < target && attempts < 20*target; attempts++ {
+		p := cells[rng.Intn(len(cells))].Add(grid.Directions[rng.Intn(4)].Offset())
+		if !blob.InBounds(p) || blob.Get(p) {
+			continue
+		}
+		blob.Set(p, true)
+		if isPinched(blob, p) || hasHole(blob) {
+			blob.Set(p, false)
+			continue
+		}
+		cells = append(cells, p)
+	}
+	return blob
+}
+
+// isPinched returns whether any 2x2 square around p has cells that only touch diagonally.
+func isPinched(blob grid.Grid[bool], p grid.Vec2) bool {
+	for dy := -1; dy <= 0; dy++ {
+		for dx := -1; dx <= 0; dx++ {
+			nw, _ := blob.Lookup(grid.Vec2{X: p.X + dx, Y: p.Y + dy})
+			ne, _ := blob.Lookup(grid.Vec2{X: p.X + dx + 1, Y: p.Y + dy})
+			sw, _ := blob.Lookup(grid.Vec2{X: p.X + dx, Y: p.Y + dy + 1})
+			se, _ := blob.Lookup(grid.Vec2{X: p.X + dx + 1, Y: p.Y + dy + 1})
+			if nw == se && ne == sw && nw != ne {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasHole returns whether any cell outside the blob is cut off from the edge of the grid.
+func hasHole(blob grid.Grid[bool]) bool {
+	var edge []grid.Vec2
+	empty := 0
+	for y := 0; y < blob.Height; y++ {
+		for x := 0; x < blob.Width; x++ {
+			p := grid.Vec2{X: x, Y: y}
+			if blob.Get(p) {
+				continue
+			}
+			empty++
+			if x == 0 || y == 0 || x == blob.Width-1 || y == blob.Height-1 {
+				edge = append(edge, p)
+			}
+		}
+	}
+	steps := blob.FloodFill(edge, func(from grid.Vec2, d grid.Direction) bool {
+		return !blob.Get(from.Add(d.Offset()))
+	})
+	reached := 0
+	for _, row := range steps.Rows() {
+		for _, step := range row {
+			if step >= 0 {
+				reached++
+			}
+		}
+	}
+	return reached != empty
+}
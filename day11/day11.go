@@ -0,0 +1,230 @@
+package day11
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+
+	"aoc/grid"
+	"aoc/input"
+	"aoc/solver"
+)
+
+type Space bool
+
+func (s Space) String() string {
+	if s {
+		return "#"
+	} else {
+		return "."
+	}
+}
+
+type Universe struct {
+	grid.Grid[Space]
+}
+
+// expand returns a copy of the universe with each empty row and column repeated times times.
+func (u Universe) expand(times int) Universe {
+	emptyRows, emptyColumns := u.getEmptyRowsColumns()
+
+	newU := grid.New[Space](u.Width+len(emptyColumns)*(times-1), u.Height+len(emptyRows)*(times-1))
+	newY := 0
+	for y, row := range u.Rows() {
+		if emptyRows[y] {
+			newY += times
+			continue
+		}
+		newX := 0
+		for x, s := range row {
+			newU.Set(grid.Vec2{X: newX, Y: newY}, s)
+			newX++
+			if emptyColumns[x] {
+				newX += times - 1
+			}
+		}
+		newY++
+	}
+	return Universe{newU}
+}
+
+func isEmpty(spaces []Space) bool {
+	for _, s := range spaces {
+		if s {
+			return false
+		}
+	}
+	return true
+}
+
+func (u Universe) getEmptyRowsColumns() (emptyRows map[int]bool, emptyColumns map[int]bool) {
+	emptyRows = make(map[int]bool)
+	for y := 0; y < u.Height; y++ {
+		if isEmpty(u.Row(y)) {
+			emptyRows[y] = true
+		}
+	}
+
+	emptyColumns = make(map[int]bool)
+	for x := 0; x < u.Width; x++ {
+		if isEmpty(u.Column(x)) {
+			emptyColumns[x] = true
+		}
+	}
+	return
+}
+
+func parseSpace(_ grid.Vec2, r rune) (Space, error) {
+	switch r {
+	case '#':
+		return true, nil
+	case '.':
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid rune %q", r)
+}
+
+func parseInput(r io.Reader) (Universe, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return Universe{}, err
+	}
+	g, err := grid.Parse(lines, parseSpace)
+	if err != nil {
+		return Universe{}, err
+	}
+	return Universe{g}, nil
+}
+
+func init() {
+	solver.Register(solver.Day{
+		Number:    11,
+		Args:      []string{"expansionFactor"},
+		New:       newSolver,
+		Generate:  generate,
+		Reference: newReferenceSolver,
+		// The default expansion makes every universe too big to expand in full
+		ReferenceArgs: [][]string{{"2"}, {"10"}, {"100"}},
+	})
+}
+
+const defaultExpandedTimes = 1000000
+
+type daySolver struct {
+	log *slog.Logger
+	// expandedTimes is how many times larger each empty row and column becomes in part 2
+	expandedTimes int
+	universe      Universe
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	s := &daySolver{log: opts.Logger(), expandedTimes: defaultExpandedTimes}
+	switch len(opts.Args) {
+	case 0:
+		break
+	case 1:
+		expandedTimes, err := strconv.Atoi(opts.Args[0])
+		if err != nil || expandedTimes < 1 {
+			return nil, fmt.Errorf("invalid expansion factor %#v. Expected a positive integer", opts.Args[0])
+		}
+		s.expandedTimes = expandedTimes
+	default:
+		return nil, fmt.Errorf("invalid arguments. Expected [expansionFactor]")
+	}
+	return s, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	universe, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	solver.DebugLines(s.log, "universe", universe.String)
+	s.universe = universe
+	return nil
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	return solver.Answer{Value: s.universe.getShortestPathSumExpanded(2)}, nil
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	return solver.Answer{Value: s.universe.getShortestPathSumExpanded(s.expandedTimes)}, nil
+}
+
+func (u Universe) getGalaxies() (galaxies []grid.Vec2) {
+	for y, row := range u.Rows() {
+		for x, s := range row {
+			if s {
+				galaxies = append(galaxies, grid.Vec2{X: x, Y: y})
+			}
+		}
+	}
+	return
+}
+
+func getShortestPathSumExpanded(galaxies []grid.Vec2, i int, emptyRows map[int]bool, emptyColumns map[int]bool, expandedTimes int) (sum int) {
+	galaxy := galaxies[i]
+	for j := i + 1; j < len(galaxies); j++ {
+		if i == j {
+			continue
+		}
+		otherGalaxy := galaxies[j]
+		minX, maxX := galaxy.X, otherGalaxy.X
+		if minX > maxX {
+			minX, maxX = maxX, minX
+		}
+		minY, maxY := galaxy.Y, otherGalaxy.Y
+		if minY > maxY {
+			minY, maxY = maxY, minY
+		}
+		distance := maxX - minX + maxY - minY
+		for x := minX + 1; x < maxX; x++ {
+			if emptyColumns[x] {
+				distance += expandedTimes - 1
+				// fmt.Printf("Empty column %v\n", x)
+			}
+		}
+		for y := minY + 1; y < maxY; y++ {
+			if emptyRows[y] {
+				distance += expandedTimes - 1
+				// fmt.Printf("Empty row %v\n", y)
+			}
+		}
+		// fmt.Printf("Galaxy %v -> %v = %v\n", galaxy, otherGalaxy, distance)
+		sum += distance
+	}
+	return
+}
+
+func (u Universe) getShortestPathSumExpanded(expandedTimes int) (sum int) {
+	emptyRows, emptyColumns := u.getEmptyRowsColumns()
+	galaxies := u.getGalaxies()
+	for i := range galaxies {
+		sum += getShortestPathSumExpanded(galaxies, i, emptyRows, emptyColumns, expandedTimes)
+	}
+	return
+}
+
+func getShortestPathSum(galaxies []grid.Vec2, i int) (sum int) {
+	galaxy := galaxies[i]
+	for j := i + 1; j < len(galaxies); j++ {
+		if i == j {
+			continue
+		}
+		otherGalaxy := galaxies[j]
+		distance := galaxy.ManhattanDistance(otherGalaxy)
+		// fmt.Printf("Galaxy %v -> %v = %v\n", galaxy, otherGalaxy, distance)
+		sum += distance
+	}
+	return
+}
+
+func (u Universe) getShortestPathSum() (sum int) {
+	galaxies := u.getGalaxies()
+	for i := range galaxies {
+		sum += getShortestPathSum(galaxies, i)
+	}
+	return
+}
@@ -0,0 +1,14 @@
+package day11
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzParseInput(f *testing.F) {
+	f.Add("...#......\n.......#..\n#.........\n")
+	f.Add("#\n")
+	f.Fuzz(func(t *testing.T, text string) {
+		parseInput(strings.NewReader(text))
+	})
+}
@@ -0,0 +1,30 @@
+package day11
+
+import (
+	"math/rand"
+
+	"aoc/grid"
+)
+
+// generate returns a random universe of size by size spaces (140 by default),
+// with a few rows and columns left empty to be expanded.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 {
+		size = 140
+	}
+	emptyRows := make([]bool, size)
+	emptyColumns := make([]bool, size)
+	for i := 0; i < size; i++ {
+		emptyRows[i] = rng.Intn(20) == 0
+		emptyColumns[i] = rng.Intn(20) == 0
+	}
+	u := Universe{grid.New[Space](size, size)}
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if !emptyRows[y] && !emptyColumns[x] && rng.Intn(50) == 0 {
+				u.Set(grid.Vec2{X: x, Y: y}, true)
+			}
+		}
+	}
+	return u.String() + "\n"
+}
@@ -0,0 +1,44 @@
+package day11
+
+import (
+	"fmt"
+
+	"aoc/solver"
+)
+
+// referenceMaxSpaces bounds the size of the universe after expansion by the reference solver
+const referenceMaxSpaces = 100000000
+
+// referenceSolver expands the universe itself and measures the distances
+// across it, rather than counting the empty rows and columns between galaxies.
+type referenceSolver struct {
+	*daySolver
+}
+
+func newReferenceSolver(opts solver.Options) (solver.Solver, error) {
+	s, err := newSolver(opts)
+	if err != nil {
+		return nil, err
+	}
+	return referenceSolver{s.(*daySolver)}, nil
+}
+
+func (s referenceSolver) Part1() (solver.Answer, error) {
+	return s.solve(2)
+}
+
+func (s referenceSolver) Part2() (solver.Answer, error) {
+	return s.solve(s.expandedTimes)
+}
+
+func (s referenceSolver) solve(times int) (solver.Answer, error) {
+	emptyRows, emptyColumns := s.universe.getEmptyRowsColumns()
+	width := s.universe.Width + len(emptyColumns)*(times-1)
+	height := s.universe.Height + len(emptyRows)*(times-1)
+	if width*height > referenceMaxSpaces {
+		return solver.Answer{}, fmt.Errorf("%w: expanded universe is %vx%v", solver.ErrTooBig, width, height)
+	}
+	expandedUniverse := s.universe.expand(times)
+	solver.DebugLines(s.log, "expanded universe", expandedUniverse.String)
+	return solver.Answer{Value: expandedUniverse.getShortestPathSum()}, nil
+}
@@ -0,0 +1,191 @@
+package day13
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"aoc/grid"
+	"aoc/input"
+	"aoc/solver"
+)
+
+type AshRock bool
+
+func (ar AshRock) isRock() bool {
+	return ar == Rock
+}
+
+func (ar AshRock) isAsh() bool {
+	return ar == Ash
+}
+
+func (ar AshRock) String() string {
+	if ar.isRock() {
+		return "#"
+	} else {
+		return "."
+	}
+}
+
+const (
+	Ash  AshRock = false
+	Rock AshRock = true
+)
+
+type Pattern struct {
+	grid.Grid[AshRock]
+}
+
+func parseAshRock(_ grid.Vec2, r rune) (AshRock, error) {
+	switch r {
+	case '#':
+		return Rock, nil
+	case '.':
+		return Ash, nil
+	}
+	return Ash, fmt.Errorf("invalid rune %q", r)
+}
+
+func parseInput(r io.Reader) ([]Pattern, error) {
+	paragraphs, err := input.Paragraphs(r)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := make([]Pattern, 0, len(paragraphs))
+	for _, paragraph := range paragraphs {
+		g, err := grid.Parse(paragraph, parseAshRock)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, Pattern{g})
+	}
+	return patterns, nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 13, New: newSolver, Generate: generate})
+}
+
+type daySolver struct {
+	log      *slog.Logger
+	patterns []Pattern
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{log: opts.Logger()}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	patterns, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	s.patterns = patterns
+	return nil
+}
+
+func logPatterns(log *slog.Logger, patterns []Pattern) {
+	for _, pattern := range patterns {
+		pattern := pattern
+		solver.DebugLines(log, "pattern", func() string {
+			var sb strings.Builder
+			printPattern(&sb, pattern)
+			return sb.String()
+		})
+	}
+}
+
+func printPattern(w io.Writer, pattern Pattern) {
+	horizontalReflection := pattern.getHorizontalReflection()
+	verticalReflection := pattern.getVerticalReflection()
+	printHorizontalNumberIndicator(w, pattern)
+	printPatternHorizontalReflectionIndicator(w, pattern, horizontalReflection)
+	for i, row := range pattern.Rows() {
+		fmt.Fprintf(w, "%v", (i+1)%10)
+		printPatternVerticalReflectionIndicator(w, verticalReflection, i)
+		for _, ar := range row {
+			fmt.Fprintf(w, "%v", ar)
+		}
+		printPatternVerticalReflectionIndicator(w, verticalReflection, i)
+		fmt.Fprintf(w, "%v\n", (i+1)%10)
+	}
+	printPatternHorizontalReflectionIndicator(w, pattern, horizontalReflection)
+	printHorizontalNumberIndicator(w, pattern)
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	logPatterns(s.log, s.patterns)
+	return solver.Answer{}, solver.ErrNotImplemented
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	logPatterns(s.log, s.patterns)
+	// TODO
+	return solver.Answer{}, solver.ErrNotImplemented
+}
+
+func (p Pattern) isValidHorizontalReflection(row []AshRock, rowI int, reflectionI int) bool {
+	startI := (rowI - len(row)/2) * 2
+	if startI < 0 {
+		startI = 0
+	}
+	for i := 0; i < reflectionI; i++ {
+
+	}
+	return true
+}
+
+func (p Pattern) getHorizontalReflection() int {
+	for reflectionI := 1; reflectionI < p.Width; reflectionI++ {
+		validReflection := true
+		for rowI, row := range p.Rows() {
+			if !p.isValidHorizontalReflection(row, rowI, reflectionI) {
+				validReflection = false
+				break
+			}
+		}
+		if validReflection {
+			return reflectionI
+		}
+	}
+	return 0
+}
+
+func (p Pattern) getVerticalReflection() int {
+	return 3
+}
+
+func printHorizontalNumberIndicator(w io.Writer, pattern Pattern) {
+	fmt.Fprintf(w, "  ")
+	for i := 0; i < pattern.Width; i++ {
+		fmt.Fprintf(w, "%v", (i+1)%10)
+	}
+	fmt.Fprintf(w, "  \n")
+}
+
+func printPatternVerticalReflectionIndicator(w io.Writer, verticalReflection int, i int) {
+	if verticalReflection > 0 && i == verticalReflection-1 {
+		fmt.Fprintf(w, "v")
+	} else if verticalReflection > 0 && i == verticalReflection {
+		fmt.Fprintf(w, "^")
+	} else {
+		fmt.Fprintf(w, " ")
+	}
+}
+
+func printPatternHorizontalReflectionIndicator(w io.Writer, pattern Pattern, horizontalReflection int) {
+	fmt.Fprintf(w, "  ")
+	for i := 0; i < pattern.Width; i++ {
+		if horizontalReflection > 0 && i == horizontalReflection-1 {
+			fmt.Fprintf(w, ">")
+		} else if horizontalReflection > 0 && i == horizontalReflection {
+			fmt.Fprintf(w, "<")
+		} else {
+			fmt.Fprintf(w, " ")
+		}
+	}
+	fmt.Fprintf(w, "  \n")
+}
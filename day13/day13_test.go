@@ -0,0 +1,21 @@
+package day13
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func FuzzParseInput(f *testing.F) {
+	f.Add("#.##..##.\n..#.##.#.\n##......#\n\n#...##..#\n#....#..#\n")
+	f.Add("#\n")
+	f.Fuzz(func(t *testing.T, text string) {
+		patterns, err := parseInput(strings.NewReader(text))
+		if err != nil {
+			return
+		}
+		for _, pattern := range patterns {
+			printPattern(io.Discard, pattern)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package day13
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+
+	"aoc/grid"
+)
+
+// generate returns size random patterns (100 by default) of 5 to 17 by 5 to 17
+// cells, each with exactly one line of reflection.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 {
+		size = 100
+	}
+	patterns := make([]Pattern, size)
+	for i := range patterns {
+		patterns[i] = generatePattern(rng)
+	}
+
+	var sb strings.Builder
+	for i, pattern := range patterns {
+		if i != 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(pattern.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func generatePattern(rng *rand.Rand) Pattern {
+	for {
+		g := grid.New[AshRock](5+rng.Intn(13), 5+rng.Intn(13))
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				g.Set(grid.Vec2{X: x, Y: y}, rng.Intn(2) == 0)
+			}
+		}
+		// Mirror the columns either side of a random vertical line, which
+		// becomes horizontal if the pattern is transposed
+		column := 1 + rng.Intn(g.Width-1)
+		for x := column; x < g.Width && 2*column-1-x >= 0; x++ {
+			for y := 0; y < g.Height; y++ {
+				g.Set(grid.Vec2{X: x, Y: y}, g.Get(grid.Vec2{X: 2*column - 1 - x, Y: y}))
+			}
+		}
+		if rng.Intn(2) == 0 {
+			g = g.Transpose()
+		}
+		if countReflections(g)+countReflections(g.Transpose()) == 1 {
+			return Pattern{g}
+		}
+	}
+}
+
+// countReflections returns the number of vertical lines that g is reflected in.
+func countReflections(g grid.Grid[AshRock]) int {
+	count := 0
+	for column := 1; column < g.Width; column++ {
+		reflected := true
+		for x := column; x < g.Width && 2*column-1-x >= 0 && reflected; x++ {
+			reflected = slices.Equal(g.Column(x), g.Column(2*column-1-x))
+		}
+		if reflected {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,136 @@
+package day2
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"aoc/input"
+)
+
+// bag is a candidate set of cubes to check the games against.
+type bag struct {
+	// Spec is the bag as it was given, e.g. "12 red, 13 green, 14 blue"
+	Spec string
+	Set  Set
+}
+
+// parseBags parses the bags given as one spec, several separated by ";", or
+// the path of a .txt file with a spec on each line, read with readFile.
+func parseBags(p *palette, spec string, readFile func(name string) ([]byte, error)) ([]bag, error) {
+	var lines []input.Line
+	isFile := filepath.Ext(spec) == ".txt"
+	if isFile {
+		data, err := readFile(spec)
+		if err != nil {
+			return nil, err
+		}
+		lines, err = input.Lines(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, line := range (input.Line{Text: spec}).Split(";") {
+			lines = append(lines, line.TrimSpace())
+		}
+	}
+
+	bags := make([]bag, 0, len(lines))
+	for _, line := range lines {
+		set, err := parseSet(p, line)
+		if err != nil {
+			if isFile {
+				input.SetFile(err, spec)
+			}
+			return nil, err
+		}
+		bags = append(bags, bag{Spec: line.Text, Set: set})
+	}
+	if len(bags) == 0 {
+		return nil, fmt.Errorf("no bags in %v", spec)
+	}
+	return bags, nil
+}
+
+// record is a draw needing more cubes of a color than any draw before it in its game.
+type record struct {
+	// Set is the index of the draw in the game
+	Set   int
+	Count int
+}
+
+// gameProfile summarises a game so that a bag can be checked without looking
+// at every draw: for each color, the draws that set a new record for it, in
+// order, so with increasing counts.
+type gameProfile struct {
+	id      int
+	records [][]record
+}
+
+func newGameProfile(game Game, colors int) gameProfile {
+	profile := gameProfile{id: game.Id, records: make([][]record, colors)}
+	for i, set := range game.Sets {
+		for color, count := range set {
+			records := profile.records[color]
+			if len(records) == 0 || count > records[len(records)-1].Count {
+				profile.records[color] = append(records, record{Set: i, Count: count})
+			}
+		}
+	}
+	return profile
+}
+
+// ruledOut returns the first draw of the game needing more cubes of a color
+// than the bag holds, and that color, or false if the game is possible.
+func (g gameProfile) ruledOut(bag Set) (record, Color, bool) {
+	first, firstColor, found := record{}, Color(0), false
+	for color, records := range g.records {
+		available := bag[Color(color)]
+		// As the counts of the records increase, the first over the bag's count
+		// is the first draw of the color that needs more cubes than it holds
+		i := sort.Search(len(records), func(i int) bool { return records[i].Count > available })
+		if i < len(records) && (!found || records[i].Set < first.Set) {
+			first, firstColor, found = records[i], Color(color), true
+		}
+	}
+	return first, firstColor, found
+}
+
+// bagReport is the outcome of checking every game against a bag.
+type bagReport struct {
+	Bag           string `json:"bag"`
+	IdSum         int    `json:"idSum"`
+	PossibleGames []int  `json:"possibleGames"`
+	// ImpossibleGames are the other games, with the first draw that rules each out
+	ImpossibleGames []impossibleGame `json:"impossibleGames"`
+}
+
+type impossibleGame struct {
+	Game int `json:"game"`
+	// Set is the 1-based number of the draw in the game
+	Set       int    `json:"set"`
+	Color     string `json:"color"`
+	Count     int    `json:"count"`
+	Available int    `json:"available"`
+}
+
+func (s *daySolver) checkBag(bag bag) bagReport {
+	report := bagReport{Bag: bag.Spec, PossibleGames: []int{}, ImpossibleGames: []impossibleGame{}}
+	for _, profile := range s.profiles {
+		draw, color, found := profile.ruledOut(bag.Set)
+		if !found {
+			report.IdSum += profile.id
+			report.PossibleGames = append(report.PossibleGames, profile.id)
+			continue
+		}
+		report.ImpossibleGames = append(report.ImpossibleGames, impossibleGame{
+			Game:      profile.id,
+			Set:       draw.Set + 1,
+			Color:     s.palette.name(color),
+			Count:     draw.Count,
+			Available: bag.Set[color],
+		})
+	}
+	return report
+}
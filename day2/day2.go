@@ -0,0 +1,310 @@
+package day2
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+// Color is a cube color, interned by a palette as its index in the palette.
+type Color int
+
+// palette interns the colors named in the input, so that they are cheap to
+// compare and can be listed in the order they were first seen.
+type palette struct {
+	names  []string
+	colors map[string]Color
+	// strict rejects any color that wasn't declared when the palette was created
+	strict bool
+}
+
+func newPalette(names ...string) *palette {
+	p := &palette{colors: make(map[string]Color)}
+	for _, name := range names {
+		p.intern(name)
+	}
+	return p
+}
+
+// newStrictPalette returns a palette of the declared colors, rejecting any others.
+func newStrictPalette(names []string) (*palette, error) {
+	p := newPalette()
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, " ,;:") {
+			return nil, fmt.Errorf("invalid color %#v", name)
+		}
+		if _, found := p.colors[name]; found {
+			return nil, fmt.Errorf("color %#v declared twice", name)
+		}
+		p.intern(name)
+	}
+	p.strict = true
+	return p, nil
+}
+
+// intern returns the color with the given name, adding it to the palette if it
+// is new, unless the palette is strict.
+func (p *palette) intern(name string) (Color, error) {
+	if color, found := p.colors[name]; found {
+		return color, nil
+	}
+	if p.strict {
+		return 0, fmt.Errorf("undeclared color %#v. Expected %v", name, strings.Join(p.names, "/"))
+	}
+	color := Color(len(p.names))
+	p.names = append(p.names, name)
+	p.colors[name] = color
+	return color, nil
+}
+
+func (p *palette) name(c Color) string {
+	return p.names[c]
+}
+
+// all returns every color in the palette.
+func (p *palette) all() []Color {
+	colors := make([]Color, len(p.names))
+	for i := range colors {
+		colors[i] = Color(i)
+	}
+	return colors
+}
+
+// formatSet formats the set as it appears in the input, e.g. "3 blue, 4 red".
+func (p *palette) formatSet(s Set) string {
+	colors := make([]Color, 0, len(s))
+	for color := range s {
+		colors = append(colors, color)
+	}
+	slices.Sort(colors)
+	items := make([]string, len(colors))
+	for i, color := range colors {
+		items[i] = fmt.Sprintf("%v %v", s[color], p.name(color))
+	}
+	return strings.Join(items, ", ")
+}
+
+type Game struct {
+	Id   int
+	Sets []Set
+}
+
+type Set map[Color]int
+
+const gamePrefix = "Game "
+
+func parseSet(p *palette, setLine input.Line) (Set, error) {
+	set := make(map[Color]int)
+	for _, setItem := range setLine.Split(",") {
+		setItem = setItem.TrimSpace()
+		setItemSplit := setItem.Split(" ")
+		if len(setItemSplit) != 2 {
+			return nil, setItem.Errorf("invalid set item %#v", setItem.Text)
+		}
+		colorCount, err := strconv.Atoi(setItemSplit[0].Text)
+		if err != nil {
+			return nil, setItemSplit[0].Errorf("invalid set item count %#v", setItemSplit[0].Text)
+		}
+		color, err := p.intern(setItemSplit[1].Text)
+		if err != nil {
+			return nil, setItemSplit[1].Error(err)
+		}
+		set[color] = colorCount
+	}
+	return set, nil
+}
+
+func parseGame(p *palette, line input.Line) (Game, error) {
+	rest, err := line.CutPrefix(gamePrefix)
+	if err != nil {
+		return Game{}, err
+	}
+
+	splitLine := rest.Split(":")
+	if len(splitLine) != 2 {
+		return Game{}, line.Errorf("line does not have a singular ':'")
+	}
+
+	gameId, err := strconv.Atoi(splitLine[0].Text)
+	if err != nil {
+		return Game{}, splitLine[0].Errorf("invalid game ID %#v", splitLine[0].Text)
+	}
+
+	var sets []Set = nil
+	for _, setLine := range splitLine[1].Split(";") {
+		set, err := parseSet(p, setLine)
+		if err != nil {
+			return Game{}, err
+		}
+		sets = append(sets, set)
+	}
+	return Game{gameId, sets}, nil
+}
+
+func parseInput(p *palette, r io.Reader) ([]Game, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]Game, 0, len(lines))
+	for _, line := range lines {
+		game, err := parseGame(p, line)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 2, Args: []string{"inputSet", "colors"}, New: newSolver, Generate: generate})
+}
+
+// defaultInputSet is the bag given in the puzzle description for part 1
+const defaultInputSet = "12 red, 13 green, 14 blue"
+
+type daySolver struct {
+	log     *slog.Logger
+	palette *palette
+	// bags are checked against the games in part 1, with a report of each
+	// added to the details of the answer if there were several or a file of
+	// them. Without an inputSet, they are only parsed from the default in part 1.
+	bags     []bag
+	report   bool
+	games    []Game
+	profiles []gameProfile
+	// colors are the colors the minimum sets cover: the declared colors in
+	// strict mode, or else every color drawn in the games
+	colors []Color
+}
+
+// newSolver accepts the optional arguments inputSet, the bag for part 1 (with
+// an empty inputSet leaving the default), and colors, a comma-separated list
+// of the only colors allowed in the bag and the input, e.g. "red,green,blue".
+// Without colors, any color is allowed. inputSet may also list several bags to
+// check, separated by ";", or be the path of a .txt file of them, one a line.
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	if len(opts.Args) > 2 {
+		return nil, fmt.Errorf("invalid arguments. Expected [inputSet] [colors]")
+	}
+	s := &daySolver{log: opts.Logger(), palette: newPalette()}
+	if len(opts.Args) >= 2 && opts.Args[1] != "" {
+		var err error
+		s.palette, err = newStrictPalette(strings.Split(strings.ReplaceAll(opts.Args[1], " ", ""), ","))
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The default bag is left to part 1, as its colors needn't be declared for part 2
+	if len(opts.Args) >= 1 && opts.Args[0] != "" {
+		inputSetString := opts.Args[0]
+		bags, err := parseBags(s.palette, inputSetString, opts.ReadArgFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid set %#v: %w", inputSetString, err)
+		}
+		s.bags = bags
+		s.report = len(bags) > 1 || filepath.Ext(inputSetString) == ".txt"
+	}
+	return s, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	games, err := parseInput(s.palette, r)
+	if err != nil {
+		return err
+	}
+	s.games = games
+	s.profiles = make([]gameProfile, len(games))
+	for i, game := range games {
+		s.profiles[i] = newGameProfile(game, len(s.palette.names))
+	}
+	if s.palette.strict {
+		s.colors = s.palette.all()
+		return nil
+	}
+	drawn := make(map[Color]bool)
+	for _, game := range games {
+		for _, set := range game.Sets {
+			for color := range set {
+				drawn[color] = true
+			}
+		}
+	}
+	s.colors = make([]Color, 0, len(drawn))
+	for color := range drawn {
+		s.colors = append(s.colors, color)
+	}
+	slices.Sort(s.colors)
+	return nil
+}
+
+func max[T constraints.Ordered](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// getMinimumInputSet returns the fewest cubes of each of the colors that the
+// game could have been played with.
+func getMinimumInputSet(game Game, colors []Color) Set {
+	minimumSet := make(Set)
+	for _, set := range game.Sets {
+		for _, color := range colors {
+			minimumSet[color] = max(minimumSet[color], set[color])
+		}
+	}
+	return minimumSet
+}
+
+func getSetPower(minimumSet Set) int {
+	power := 1
+	for _, count := range minimumSet {
+		power *= count
+	}
+	return power
+}
+
+// Part1 sums the IDs of the games possible with each bag. The answer is the
+// sum for the first bag, so that with one bag it is the puzzle's answer.
+func (s *daySolver) Part1() (solver.Answer, error) {
+	if s.bags == nil {
+		bags, err := parseBags(s.palette, defaultInputSet, nil)
+		if err != nil {
+			return solver.Answer{}, fmt.Errorf("invalid default set %#v: %w", defaultInputSet, err)
+		}
+		s.bags = bags
+	}
+	reports := make([]bagReport, len(s.bags))
+	for i, bag := range s.bags {
+		reports[i] = s.checkBag(bag)
+		s.log.Debug("bag", "bag", bag.Spec, "idSum", reports[i].IdSum, "possible", len(reports[i].PossibleGames), "impossible", len(reports[i].ImpossibleGames))
+	}
+	answer := solver.Answer{Value: reports[0].IdSum}
+	if s.report {
+		answer.Details = reports
+	}
+	return answer, nil
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	powerSum := 0
+	for _, game := range s.games {
+		minimumSet := getMinimumInputSet(game, s.colors)
+		minimumSetPower := getSetPower(minimumSet)
+		s.log.Debug("minimum set", "game", game.Id, "set", s.palette.formatSet(minimumSet), "power", minimumSetPower)
+		powerSum += minimumSetPower
+	}
+	return solver.Answer{Value: powerSum}, nil
+}
@@ -0,0 +1,185 @@
+package day2
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+func FuzzParseGame(f *testing.F) {
+	f.Add("Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green")
+	f.Add("Game 100: 1 red")
+	f.Fuzz(func(t *testing.T, text string) {
+		_, err := parseGame(newPalette(), input.Line{Number: 1, Text: text})
+		var parseErr *input.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			t.Errorf("got %v, expected a *input.ParseError", err)
+		}
+	})
+}
+
+func solveGames(t *testing.T, text string, args ...string) (int, int, error) {
+	t.Helper()
+	s, err := newSolver(solver.Options{Args: args})
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := s.Parse(strings.NewReader(text)); err != nil {
+		return 0, 0, err
+	}
+	part1, err := s.Part1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	part2, err := s.Part2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return part1.Value, part2.Value, nil
+}
+
+func TestDynamicColors(t *testing.T) {
+	const text = "Game 1: 3 blue, 4 red, 2 yellow; 1 red, 2 green, 6 blue\n" +
+		"Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red, 5 yellow\n"
+	part1, part2, err := solveGames(t, text, "12 red, 13 green, 14 blue, 4 yellow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Game 2 needs 5 yellow cubes, and the minimum sets are 4 red, 2 green, 6 blue, 2 yellow and 1 red, 3 green, 4 blue, 5 yellow
+	if part1 != 1 || part2 != 4*2*6*2+1*3*4*5 {
+		t.Errorf("got %v, %v, expected 1, %v", part1, part2, 4*2*6*2+1*3*4*5)
+	}
+	// Without yellow cubes in the bag, no game is possible
+	if part1, _, err := solveGames(t, text); err != nil || part1 != 0 {
+		t.Errorf("got %v (%v), expected 0", part1, err)
+	}
+
+	_, _, err = solveGames(t, text, "", "red, green, blue")
+	var parseErr *input.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 1 || parseErr.Column != 26 || !strings.Contains(err.Error(), `undeclared color "yellow"`) {
+		t.Errorf("got %v, expected undeclared yellow at 1:26", err)
+	}
+	if _, _, err := solveGames(t, text, "1 purple", "red,green,blue,yellow"); err == nil {
+		t.Errorf("got no error for an undeclared color in the bag")
+	}
+	if _, _, err := solveGames(t, text, "", "red,green,red"); err == nil || !strings.Contains(err.Error(), "declared twice") {
+		t.Errorf("got %v, expected red to be declared twice", err)
+	}
+
+	// The default bag is only needed by part 1, so its colors needn't be declared for part 2
+	s, err := newSolver(solver.Options{Args: []string{"", "yellow,red,green,blue"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Parse(strings.NewReader(text)); err != nil {
+		t.Fatal(err)
+	}
+	if part2, err := s.Part2(); err != nil || part2.Value != 4*2*6*2+1*3*4*5 {
+		t.Errorf("got %v (%v), expected %v", part2.Value, err, 4*2*6*2+1*3*4*5)
+	}
+	s, err = newSolver(solver.Options{Args: []string{"", "yellow,purple"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Parse(strings.NewReader("Game 1: 2 yellow; 1 purple, 3 yellow\nGame 2: 4 purple\n")); err != nil {
+		t.Fatal(err)
+	}
+	if part2, err := s.Part2(); err != nil || part2.Value != 3*1+0*4 {
+		t.Errorf("got %v (%v), expected 3", part2.Value, err)
+	}
+	if _, err := s.Part1(); err == nil || !strings.Contains(err.Error(), `undeclared color "red"`) {
+		t.Errorf("got %v, expected the default bag's red to be undeclared", err)
+	}
+
+	// A declared color is in every minimum set, so games without it have power 0
+	_, part2, err = solveGames(t, text, "", "red,green,blue,yellow,purple")
+	if err != nil || part2 != 0 {
+		t.Errorf("got %v (%v), expected 0", part2, err)
+	}
+}
+
+func TestBagQueries(t *testing.T) {
+	const text = "Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green\n" +
+		"Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue\n" +
+		"Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red\n"
+	bagsPath := filepath.Join(t.TempDir(), "bags.txt")
+	if err := os.WriteFile(bagsPath, []byte("12 red, 13 green, 14 blue\n\n4 red, 3 green, 5 blue\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newSolver(solver.Options{Args: []string{bagsPath}}); !errors.Is(err, solver.ErrNoFiles) {
+		t.Errorf("got %v without a way to read files, expected %v", err, solver.ErrNoFiles)
+	}
+	s, err := newSolver(solver.Options{Args: []string{bagsPath}, ReadFile: os.ReadFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Parse(strings.NewReader(text)); err != nil {
+		t.Fatal(err)
+	}
+	answer, err := s.Part1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports := answer.Details.([]bagReport)
+	if answer.Value != 3 || len(reports) != 2 {
+		t.Fatalf("got %v with %v reports, expected 3 with 2 reports", answer.Value, len(reports))
+	}
+	expected := bagReport{
+		Bag:           "4 red, 3 green, 5 blue",
+		IdSum:         2,
+		PossibleGames: []int{2},
+		ImpossibleGames: []impossibleGame{
+			{Game: 1, Set: 2, Color: "blue", Count: 6, Available: 5},
+			{Game: 3, Set: 1, Color: "red", Count: 20, Available: 4},
+		},
+	}
+	if got := reports[1]; got.Bag != expected.Bag || got.IdSum != expected.IdSum || !slices.Equal(got.PossibleGames, expected.PossibleGames) || !slices.Equal(got.ImpossibleGames, expected.ImpossibleGames) {
+		t.Errorf("got %+v, expected %+v", got, expected)
+	}
+
+	// One bag gives no report, to keep the answer as it was
+	if part1, _, err := solveGames(t, text); err != nil || part1 != 3 {
+		t.Errorf("got %v (%v), expected 3", part1, err)
+	}
+	if _, _, err := solveGames(t, text, "12 red; 3 purple,"); err == nil {
+		t.Errorf("got no error for an invalid bag in a list")
+	}
+}
+
+// TestGameProfile checks the preprocessed games rule out the same games as
+// checking every draw, at the first draw that needs too many cubes.
+func TestGameProfile(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	p := newPalette()
+	games, err := parseInput(p, strings.NewReader(generate(rng, 200)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		bag := Set{}
+		for _, color := range p.all() {
+			bag[color] = rng.Intn(21)
+		}
+		for _, game := range games {
+			expectedSet := -1
+			for j, set := range game.Sets {
+				for color, count := range set {
+					if count > bag[color] && expectedSet < 0 {
+						expectedSet = j
+					}
+				}
+			}
+			draw, color, found := newGameProfile(game, len(p.names)).ruledOut(bag)
+			if found != (expectedSet >= 0) || found && (draw.Set != expectedSet || game.Sets[draw.Set][color] <= bag[color]) {
+				t.Fatalf("game %v with bag %v: got %+v of %v (%v), expected set %v", game.Id, p.formatSet(bag), draw, p.name(color), found, expectedSet)
+			}
+		}
+	}
+}
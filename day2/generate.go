@@ -0,0 +1,41 @@
+package day2
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// generate returns size random games (100 by default), each with a few sets of
+// up to 20 cubes of each color.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 {
+		size = 100
+	}
+	p := newPalette("red", "green", "blue")
+	colors := p.all()
+	games := make([]Game, size)
+	for i := range games {
+		games[i].Id = i + 1
+		for j := 1 + rng.Intn(6); j > 0; j-- {
+			set := make(Set)
+			for _, color := range rng.Perm(len(colors))[:1+rng.Intn(len(colors))] {
+				set[colors[color]] = 1 + rng.Intn(20)
+			}
+			games[i].Sets = append(games[i].Sets, set)
+		}
+	}
+
+	var sb strings.Builder
+	for _, game := range games {
+		fmt.Fprintf(&sb, "%v%v: ", gamePrefix, game.Id)
+		for i, set := range game.Sets {
+			if i != 0 {
+				sb.WriteString("; ")
+			}
+			sb.WriteString(p.formatSet(set))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,224 @@
+package day3
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+
+	"aoc/grid"
+	"aoc/input"
+	"aoc/solver"
+)
+
+type Engine struct {
+	grid.Grid[rune]
+}
+
+func parseInput(r io.Reader) (Engine, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return Engine{}, err
+	}
+	g, err := grid.Parse(lines, func(_ grid.Vec2, r rune) (rune, error) { return r, nil })
+	if err != nil {
+		return Engine{}, err
+	}
+	return Engine{g}, nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 3, New: newSolver})
+}
+
+type daySolver struct {
+	log    *slog.Logger
+	engine Engine
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{log: opts.Logger()}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	engine, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	solver.DebugLines(s.log, "schematic", engine.String)
+	s.engine = engine
+	return nil
+}
+
+func isDigit(c rune) bool {
+	_, ok := parseDigit(c)
+	return ok
+}
+
+func parseDigit(c rune) (int, bool) {
+	switch c {
+	case '0':
+		return 0, true
+	case '1':
+		return 1, true
+	case '2':
+		return 2, true
+	case '3':
+		return 3, true
+	case '4':
+		return 4, true
+	case '5':
+		return 5, true
+	case '6':
+		return 6, true
+	case '7':
+		return 7, true
+	case '8':
+		return 8, true
+	case '9':
+		return 9, true
+	}
+	return 0, false
+}
+
+func getRune(engine Engine, x int, y int) rune {
+	return engine.Get(grid.Vec2{X: x, Y: y})
+}
+
+func isDigitAt(engine Engine, x int, y int) bool {
+	c, ok := engine.Lookup(grid.Vec2{X: x, Y: y})
+	return ok && isDigit(c)
+}
+
+func isSymbol(engine Engine, x int, y int) bool {
+	c, ok := engine.Lookup(grid.Vec2{X: x, Y: y})
+	return ok && !isDigit(c) && c != '.'
+}
+
+func isAdjacentToSymbol(engine Engine, x int, y int) bool {
+	for _, n := range engine.Neighbours8(grid.Vec2{X: x, Y: y}) {
+		if isSymbol(engine, n.X, n.Y) {
+			return true
+		}
+	}
+	return false
+}
+
+func getPartNumbers(log *slog.Logger, engine Engine) []int {
+	var numbers []int
+	for y, line := range engine.Rows() {
+		var rowNumbers, rowIgnored []int
+		var numberBuffer int
+		var numberBufferX int
+		for x := 0; x <= len(line); x++ {
+			var digit int
+			var isDigit bool
+			if x < len(line) {
+				c := getRune(engine, x, y)
+				digit, isDigit = parseDigit(c)
+			}
+			if isDigit {
+				if numberBuffer == 0 {
+					numberBufferX = x
+				}
+				numberBuffer *= 10
+				numberBuffer += digit
+			} else if numberBuffer != 0 {
+				isPartNumber := false
+				for numberX := numberBufferX; numberX < x && numberX < len(line); numberX++ {
+					if isAdjacentToSymbol(engine, numberX, y) {
+						isPartNumber = true
+						break
+					}
+				}
+
+				if isPartNumber {
+					rowNumbers = append(rowNumbers, numberBuffer)
+				} else {
+					rowIgnored = append(rowIgnored, numberBuffer)
+				}
+
+				numberBuffer = 0
+				numberBufferX = 0
+			}
+		}
+		log.Debug("part numbers", "y", y, "numbers", rowNumbers, "ignored", rowIgnored)
+		numbers = append(numbers, rowNumbers...)
+	}
+	return numbers
+}
+
+// getNumberStart returns the position of the first digit of the number with a digit at p.
+func getNumberStart(engine Engine, p grid.Vec2) grid.Vec2 {
+	for isDigitAt(engine, p.X-1, p.Y) {
+		p.X--
+	}
+	return p
+}
+
+// getNumberAt returns the number starting at p.
+func getNumberAt(engine Engine, p grid.Vec2) int {
+	number := 0
+	for x := p.X; x < engine.Width; x++ {
+		digit, ok := parseDigit(getRune(engine, x, p.Y))
+		if !ok {
+			break
+		}
+		number = number*10 + digit
+	}
+	return number
+}
+
+func getGearRatio(log *slog.Logger, engine Engine, x int, y int) (int, bool) {
+	// A number can be next to the * at several of its digits, so each is found by where it starts
+	var starts []grid.Vec2
+	var gearNumbers []int
+	for _, n := range engine.Neighbours8(grid.Vec2{X: x, Y: y}) {
+		if !isDigitAt(engine, n.X, n.Y) {
+			continue
+		}
+		start := getNumberStart(engine, n)
+		if !slices.Contains(starts, start) {
+			starts = append(starts, start)
+			gearNumbers = append(gearNumbers, getNumberAt(engine, start))
+		}
+	}
+	log.Debug("gear", "x", x, "y", y, "numbers", gearNumbers)
+	// A gear is a * next to exactly two numbers
+	if len(gearNumbers) != 2 {
+		return 0, false
+	}
+	return gearNumbers[0] * gearNumbers[1], true
+}
+
+func getGearRatios(log *slog.Logger, engine Engine) []int {
+	var gearRatios []int
+	for y, line := range engine.Rows() {
+		for x := 0; x < len(line); x++ {
+			if line[x] == '*' {
+				gearRatio, ok := getGearRatio(log, engine, x, y)
+				if ok {
+					gearRatios = append(gearRatios, gearRatio)
+				}
+			}
+		}
+	}
+	return gearRatios
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	numbers := getPartNumbers(s.log, s.engine)
+	sum := 0
+	for _, number := range numbers {
+		sum += number
+	}
+	return solver.Answer{Value: sum}, nil
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	gearRatios := getGearRatios(s.log, s.engine)
+	sum := 0
+	for _, gearRatio := range gearRatios {
+		sum += gearRatio
+	}
+	return solver.Answer{Value: sum}, nil
+}
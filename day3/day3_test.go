@@ -0,0 +1,53 @@
+package day3
+
+import (
+	"strings"
+	"testing"
+
+	"aoc/solver"
+)
+
+func FuzzParseInput(f *testing.F) {
+	f.Add("467..114..\n...*......\n..35..633.\n")
+	f.Add("1\n")
+	f.Add("1.2\n.*.\n3.4\n")
+	f.Fuzz(func(t *testing.T, text string) {
+		s, err := newSolver(solver.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Parse(strings.NewReader(text)); err != nil {
+			return
+		}
+		s.Part1()
+		s.Part2()
+	})
+}
+
+func TestGearRatios(t *testing.T) {
+	tests := []struct {
+		schematic string
+		expected  int
+	}{
+		{"467..114..\n...*......\n..35..633.\n", 467 * 35},
+		{"12*34\n", 12 * 34},
+		{"123\n.*.\n45.\n", 123 * 45},
+		// Next to three or more numbers, a * is not a gear
+		{"1.2\n.*.\n3.4\n", 0},
+		{"11.\n.*5\n.7.\n", 0},
+		{"..8\n.*.\n", 0},
+	}
+	for _, test := range tests {
+		s, err := newSolver(solver.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Parse(strings.NewReader(test.schematic)); err != nil {
+			t.Fatal(err)
+		}
+		answer, err := s.Part2()
+		if err != nil || answer.Value != test.expected {
+			t.Errorf("%#v: got %v (%v), expected %v", test.schematic, answer.Value, err, test.expected)
+		}
+	}
+}
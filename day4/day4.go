@@ -0,0 +1,148 @@
+package day4
+
+import (
+	"io"
+	"slices"
+	"strconv"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+type Card struct {
+	Id      int
+	Winning []int
+	Numbers []int
+}
+
+const cardPrefix = "Card "
+
+func parseCard(line input.Line) (Card, error) {
+	rest, err := line.CutPrefix(cardPrefix)
+	if err != nil {
+		return Card{}, err
+	}
+
+	splitLine := rest.Split(":")
+	if len(splitLine) != 2 {
+		return Card{}, line.Errorf("line does not have a singular ':'")
+	}
+
+	cardIdLine := splitLine[0].TrimSpace()
+	cardId, err := strconv.Atoi(cardIdLine.Text)
+	if err != nil {
+		return Card{}, cardIdLine.Errorf("invalid card ID %#v", cardIdLine.Text)
+	}
+	if cardId < 1 {
+		return Card{}, cardIdLine.Errorf("invalid card ID %v. Cards are numbered from 1", cardId)
+	}
+
+	winningNonSplit := splitLine[1].Split("|")
+	if len(winningNonSplit) != 2 {
+		return Card{}, line.Errorf("line does not have a singular '|'")
+	}
+
+	winning, err := winningNonSplit[0].Ints()
+	if err != nil {
+		return Card{}, err
+	}
+
+	numbers, err := winningNonSplit[1].Ints()
+	if err != nil {
+		return Card{}, err
+	}
+
+	return Card{cardId, winning, numbers}, nil
+}
+
+func parseInput(r io.Reader) ([]Card, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]Card, 0, len(lines))
+	for _, line := range lines {
+		card, err := parseCard(line)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 4, New: newSolver, Generate: generate})
+}
+
+type daySolver struct {
+	cards []Card
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	cards, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	s.cards = cards
+	return nil
+}
+
+func (c Card) getMatchingWinningNumbersCount() int {
+	count := 0
+	for _, number := range c.Numbers {
+		if slices.Contains(c.Winning, number) {
+			count += 1
+		}
+	}
+	return count
+}
+
+func intPow(n, m int) int {
+	if m == 0 {
+		return 1
+	}
+	result := n
+	for i := 2; i <= m; i++ {
+		result *= n
+	}
+	return result
+}
+
+func (c Card) getPoints() int {
+	count := c.getMatchingWinningNumbersCount()
+	if count == 0 {
+		return 0
+	} else {
+		return intPow(2, count-1)
+	}
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	pointSum := 0
+	for _, card := range s.cards {
+		pointSum += card.getPoints()
+	}
+	return solver.Answer{Value: pointSum}, nil
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	originalCards := s.cards
+	cards := slices.Clone(originalCards)
+	for i := 0; i < len(cards); i++ {
+		card := cards[i]
+		// fmt.Printf("%+v\n", card)
+		winningCount := card.getMatchingWinningNumbersCount()
+		for j := card.Id; j < len(originalCards) && j < card.Id+winningCount; j++ {
+			extraCard := originalCards[j]
+			// fmt.Printf("+ %+v\n", extraCard)
+			cards = append(cards, extraCard)
+		}
+	}
+	return solver.Answer{Value: len(cards)}, nil
+}
@@ -0,0 +1,31 @@
+package day4
+
+import (
+	"errors"
+	"testing"
+
+	"aoc/input"
+)
+
+func FuzzParseCard(f *testing.F) {
+	f.Add("Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53")
+	f.Add("Card   12:  1 | 1")
+	f.Add("Card -1: 1 | 1")
+	f.Fuzz(func(t *testing.T, text string) {
+		_, err := parseCard(input.Line{Number: 1, Text: text})
+		var parseErr *input.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			t.Errorf("got %v, expected a *input.ParseError", err)
+		}
+	})
+}
+
+func TestParseCardInvalidId(t *testing.T) {
+	for _, text := range []string{"Card 0: 1 | 1", "Card -1: 1 | 1", "Card x: 1 | 1"} {
+		_, err := parseCard(input.Line{Number: 1, Text: text})
+		var parseErr *input.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Column != 6 {
+			t.Errorf("%#v: got %v, expected an error at column 6", text, err)
+		}
+	}
+}
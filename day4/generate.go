@@ -0,0 +1,47 @@
+package day4
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// generate returns size random cards (200 by default), each with 10 winning
+// numbers and 25 numbers. Cards win less than one copy on average, so that
+// part 2's count stays well within an int.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 {
+		size = 200
+	}
+	cards := make([]Card, size)
+	for i := range cards {
+		numbers := rng.Perm(99)
+		for j := range numbers {
+			numbers[j]++
+		}
+		winning := numbers[:10]
+		// Never win copies of cards past the end of the table
+		matches := 0
+		if rng.Intn(4) == 0 {
+			matches = min(1+rng.Intn(3), size-1-i)
+		}
+		have := append(slices.Clone(winning[:matches]), numbers[10:10+25-matches]...)
+		rng.Shuffle(len(have), func(a, b int) { have[a], have[b] = have[b], have[a] })
+		cards[i] = Card{Id: i + 1, Winning: winning, Numbers: have}
+	}
+
+	var sb strings.Builder
+	for _, card := range cards {
+		fmt.Fprintf(&sb, "%v%3v: %v | %v\n", cardPrefix, card.Id, formatNumbers(card.Winning), formatNumbers(card.Numbers))
+	}
+	return sb.String()
+}
+
+func formatNumbers(numbers []int) string {
+	formatted := make([]string, len(numbers))
+	for i, number := range numbers {
+		formatted[i] = fmt.Sprintf("%2v", number)
+	}
+	return strings.Join(formatted, " ")
+}
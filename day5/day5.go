@@ -0,0 +1,249 @@
+package day5
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+type RangeMapItem struct {
+	SourceStart      int
+	DestinationStart int
+	Length           int
+}
+
+type RangeMap []RangeMapItem
+
+func (rangeMap RangeMap) getDestination(source int) int {
+	for _, item := range rangeMap {
+		if source >= item.SourceStart && source < item.SourceStart+item.Length {
+			return source - item.SourceStart + item.DestinationStart
+		}
+	}
+	return source
+}
+
+func (rangeMap RangeMap) getDestinations(sourceRanges []Range) []Range {
+	destinationRanges := make([]Range, 0, len(sourceRanges))
+	// fmt.Printf("  # %+v\n", rangeMap)
+	for _, sourceRange := range sourceRanges {
+		// fmt.Printf("  <- %v\n", sourceRange)
+		for _, item := range rangeMap {
+			if sourceRange.Start < item.SourceStart {
+				destinationRangeLength := min(sourceRange.Length, item.SourceStart-sourceRange.Start)
+				destinationRange := Range{Start: sourceRange.Start, Length: destinationRangeLength}
+				destinationRanges = append(destinationRanges, destinationRange)
+
+				sourceRangeLength := sourceRange.Length - destinationRangeLength
+				sourceRange = Range{Start: item.SourceStart, Length: sourceRangeLength}
+				// fmt.Printf("    + %v (%v)\n", destinationRange, sourceRange)
+				if sourceRange.Length <= 0 {
+					break
+				}
+			}
+
+			if sourceRange.Start < item.SourceStart+item.Length {
+				destinationRangeLength := min(sourceRange.Length, item.Length-sourceRange.Start+item.SourceStart)
+				destinationRange := Range{Start: sourceRange.Start - item.SourceStart + item.DestinationStart, Length: destinationRangeLength}
+				destinationRanges = append(destinationRanges, destinationRange)
+
+				sourceRangeLength := sourceRange.Length - destinationRangeLength
+				sourceRange = Range{Start: item.SourceStart + item.Length, Length: sourceRangeLength}
+				// fmt.Printf("    + %v (%v)\n", destinationRange, sourceRange)
+				if sourceRange.Length <= 0 {
+					break
+				}
+			}
+		}
+		if sourceRange.Length > 0 {
+			// fmt.Printf("  + %v\n", sourceRange)
+			destinationRanges = append(destinationRanges, sourceRange)
+		}
+	}
+	slices.SortFunc(destinationRanges, func(a, b Range) int { return a.Start - b.Start })
+	// fmt.Printf("  @- %v\n", destinationRanges)
+	var newDestinationRanges []Range
+	for i, destRange := range destinationRanges {
+		if i == 0 {
+			newDestinationRanges = append(newDestinationRanges, destRange)
+			continue
+		}
+
+		prevDestinationRange := newDestinationRanges[len(newDestinationRanges)-1]
+		// fmt.Printf("  > %v <= %v + %v = %v\n", destRange.Start, prevDestinationRange.Start, prevDestinationRange.Length, prevDestinationRange.Start+prevDestinationRange.Length)
+		if destRange.Start <= prevDestinationRange.Start+prevDestinationRange.Length {
+			// Merge ranges
+			newDestinationRanges[len(newDestinationRanges)-1] = Range{Start: prevDestinationRange.Start, Length: max(prevDestinationRange.Length, destRange.Start+destRange.Length-prevDestinationRange.Start)}
+		} else {
+			newDestinationRanges = append(newDestinationRanges, destRange)
+		}
+	}
+	// fmt.Printf("  @= %v\n", newDestinationRanges)
+	return newDestinationRanges
+}
+
+type Almanac struct {
+	Seeds                 []int
+	SeedToSoil            RangeMap
+	SoilToFertilizer      RangeMap
+	FertilizerToWater     RangeMap
+	WaterToLight          RangeMap
+	LightToTemperature    RangeMap
+	TemperatureToHumidity RangeMap
+	HumidityToLocation    RangeMap
+}
+
+type Range struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+func parseMap(lines []input.Line) (RangeMap, error) {
+	rangeMap := make(RangeMap, 0, len(lines))
+	for _, line := range lines {
+		numbers, err := line.Ints()
+		if err != nil {
+			return nil, err
+		}
+		if len(numbers) != 3 {
+			return nil, line.Errorf("invalid line: Expected 3 numbers, got: %#v", numbers)
+		}
+		if numbers[2] < 0 {
+			return nil, line.Errorf("invalid line: Negative range length %v", numbers[2])
+		}
+		rangeMap = append(rangeMap, RangeMapItem{SourceStart: numbers[1], DestinationStart: numbers[0], Length: numbers[2]})
+	}
+	slices.SortFunc(rangeMap, func(a, b RangeMapItem) int { return a.SourceStart - b.SourceStart })
+	return rangeMap, nil
+}
+
+func parseInput(r io.Reader) (Almanac, error) {
+	paragraphs, err := input.Paragraphs(r)
+	if err != nil {
+		return Almanac{}, err
+	}
+
+	var almanac Almanac
+	for _, paragraph := range paragraphs {
+		line := paragraph[0]
+		splitLine := line.Split(":")
+		if len(splitLine) != 2 {
+			return Almanac{}, line.Errorf("invalid line: Expected singular ':'")
+		}
+
+		key := splitLine[0].TrimSpace()
+		switch key.Text {
+		case "seeds":
+			if len(paragraph) != 1 {
+				return Almanac{}, paragraph[1].Errorf("invalid line: Expected blank line after seeds")
+			}
+			almanac.Seeds, err = splitLine[1].Ints()
+		case "seed-to-soil map":
+			almanac.SeedToSoil, err = parseMap(paragraph[1:])
+		case "soil-to-fertilizer map":
+			almanac.SoilToFertilizer, err = parseMap(paragraph[1:])
+		case "fertilizer-to-water map":
+			almanac.FertilizerToWater, err = parseMap(paragraph[1:])
+		case "water-to-light map":
+			almanac.WaterToLight, err = parseMap(paragraph[1:])
+		case "light-to-temperature map":
+			almanac.LightToTemperature, err = parseMap(paragraph[1:])
+		case "temperature-to-humidity map":
+			almanac.TemperatureToHumidity, err = parseMap(paragraph[1:])
+		case "humidity-to-location map":
+			almanac.HumidityToLocation, err = parseMap(paragraph[1:])
+		default:
+			return Almanac{}, key.Errorf("invalid line: Unknown key %#v", key.Text)
+		}
+		if err != nil {
+			return Almanac{}, err
+		}
+	}
+	return almanac, nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 5, New: newSolver, Generate: generate, Reference: newReferenceSolver})
+}
+
+// StageRanges holds the ranges of values reached at one stage of the almanac.
+type StageRanges struct {
+	Stage  string  `json:"stage"`
+	Ranges []Range `json:"ranges"`
+}
+
+type daySolver struct {
+	log     *slog.Logger
+	almanac Almanac
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{log: opts.Logger()}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	almanac, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	s.almanac = almanac
+	return nil
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	almanac := s.almanac
+	seedToLocation := []RangeMap{almanac.SeedToSoil, almanac.SoilToFertilizer, almanac.FertilizerToWater, almanac.WaterToLight, almanac.LightToTemperature, almanac.TemperatureToHumidity, almanac.HumidityToLocation}
+	var locations []int
+	for _, seed := range almanac.Seeds {
+		value := seed
+		path := []int{value}
+		for _, rangeMap := range seedToLocation {
+			value = rangeMap.getDestination(value)
+			path = append(path, value)
+		}
+		s.log.Debug("seed to location", "path", path)
+		locations = append(locations, value)
+	}
+	s.log.Debug("locations", "locations", locations)
+	if len(locations) == 0 {
+		return solver.Answer{}, errors.New("no seeds")
+	}
+	return solver.Answer{Value: slices.Min(locations)}, nil
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	almanac := s.almanac
+	names := []string{"Seed", "Soil", "Fertilizer", "Water", "Light", "Temperature", "Humidity", "Location"}
+	seedToLocation := []RangeMap{almanac.SeedToSoil, almanac.SoilToFertilizer, almanac.FertilizerToWater, almanac.WaterToLight, almanac.LightToTemperature, almanac.TemperatureToHumidity, almanac.HumidityToLocation}
+	if len(almanac.Seeds)%2 != 0 {
+		return solver.Answer{}, fmt.Errorf("odd number of seeds (%v), expected pairs of range start and length", len(almanac.Seeds))
+	}
+	var values []Range
+	for i := 0; i < len(almanac.Seeds); i += 2 {
+		if almanac.Seeds[i+1] < 0 {
+			return solver.Answer{}, fmt.Errorf("negative seed range length %v", almanac.Seeds[i+1])
+		}
+		values = append(values, Range{Start: almanac.Seeds[i], Length: almanac.Seeds[i+1]})
+	}
+	var stages []StageRanges
+	for i, rangeMap := range seedToLocation {
+		s.log.Debug("ranges", "stage", names[i], "ranges", values)
+		stages = append(stages, StageRanges{Stage: names[i], Ranges: values})
+		values = rangeMap.getDestinations(values)
+	}
+	s.log.Debug("ranges", "stage", names[len(names)-1], "ranges", values)
+	stages = append(stages, StageRanges{Stage: names[len(names)-1], Ranges: values})
+	if len(values) == 0 {
+		return solver.Answer{}, errors.New("no seeds")
+	}
+	minLocations := make([]int, 0, len(values))
+	for _, locationRange := range values {
+		minLocations = append(minLocations, locationRange.Start)
+	}
+	return solver.Answer{Value: slices.Min(minLocations), Details: stages}, nil
+}
@@ -0,0 +1,36 @@
+package day5
+
+import (
+	"strings"
+	"testing"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+func FuzzParseMap(f *testing.F) {
+	f.Add("50 98 2\n52 50 48")
+	f.Add("0 15 37\n37 52 2\n39 0 15")
+	f.Fuzz(func(t *testing.T, text string) {
+		lines, err := input.Lines(strings.NewReader(text))
+		if err != nil {
+			t.Skip()
+		}
+		parseMap(lines)
+	})
+}
+
+// FuzzSolve checks that both parts return an error rather than panicking on
+// any almanac that parses.
+func FuzzSolve(f *testing.F) {
+	f.Add("seeds: 79 14 55 13\n\nseed-to-soil map:\n50 98 2\n52 50 48\n")
+	f.Add("seeds: 79 14 55\n\nsoil-to-fertilizer map:\n0 15 37\n")
+	f.Fuzz(func(t *testing.T, text string) {
+		s, _ := newSolver(solver.Options{})
+		if err := s.Parse(strings.NewReader(text)); err != nil {
+			return
+		}
+		s.Part1()
+		s.Part2()
+	})
+}
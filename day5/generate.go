@@ -0,0 +1,66 @@
+package day5
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// generate returns a random almanac with 10 seed ranges, and size ranges in
+// each map. The source ranges of a map never overlap. By default there are 20
+// ranges in each map and the numbers go up to 2^32 like the real almanacs;
+// otherwise they stay below 100*size, so that small almanacs have few seeds.
+func generate(rng *rand.Rand, size int) string {
+	limit := 100 * size
+	if size <= 0 {
+		size = 20
+		limit = 1 << 32
+	}
+	var almanac Almanac
+	for i := 0; i < 10; i++ {
+		start := rng.Intn(limit)
+		length := 1 + rng.Intn(max(1, min(limit/50, limit-start)))
+		almanac.Seeds = append(almanac.Seeds, start, length)
+	}
+	maps := []*RangeMap{&almanac.SeedToSoil, &almanac.SoilToFertilizer, &almanac.FertilizerToWater, &almanac.WaterToLight, &almanac.LightToTemperature, &almanac.TemperatureToHumidity, &almanac.HumidityToLocation}
+	for _, rangeMap := range maps {
+		*rangeMap = generateMap(rng, size, limit)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("seeds:")
+	for _, seed := range almanac.Seeds {
+		fmt.Fprintf(&sb, " %v", seed)
+	}
+	sb.WriteString("\n")
+	names := []string{"seed-to-soil", "soil-to-fertilizer", "fertilizer-to-water", "water-to-light", "light-to-temperature", "temperature-to-humidity", "humidity-to-location"}
+	for i, rangeMap := range maps {
+		fmt.Fprintf(&sb, "\n%v map:\n", names[i])
+		for _, item := range *rangeMap {
+			fmt.Fprintf(&sb, "%v %v %v\n", item.DestinationStart, item.SourceStart, item.Length)
+		}
+	}
+	return sb.String()
+}
+
+// generateMap cuts the numbers below limit into up to 2*size pieces, and maps
+// every other one to a random destination.
+func generateMap(rng *rand.Rand, size int, limit int) RangeMap {
+	cuts := []int{0, limit}
+	for i := 0; i < 2*size-1; i++ {
+		cut := rng.Intn(limit)
+		if !slices.Contains(cuts, cut) {
+			cuts = append(cuts, cut)
+		}
+	}
+	slices.Sort(cuts)
+	rangeMap := make(RangeMap, 0, size)
+	for i := rng.Intn(2); i+1 < len(cuts); i += 2 {
+		length := cuts[i+1] - cuts[i]
+		destination := rng.Intn(limit - length + 1)
+		rangeMap = append(rangeMap, RangeMapItem{SourceStart: cuts[i], DestinationStart: destination, Length: length})
+	}
+	rng.Shuffle(len(rangeMap), func(a, b int) { rangeMap[a], rangeMap[b] = rangeMap[b], rangeMap[a] })
+	return rangeMap
+}
@@ -0,0 +1,76 @@
+package day5
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"aoc/solver"
+)
+
+// referenceMaxSeeds bounds the number of seeds the reference solver maps one at a time
+const referenceMaxSeeds = 10000000
+
+// referenceSolver swaps the methods of the two parts, mapping the seeds of
+// part 1 as ranges of one seed, and mapping each seed in part 2's ranges on its own.
+type referenceSolver struct {
+	*daySolver
+}
+
+func newReferenceSolver(opts solver.Options) (solver.Solver, error) {
+	s, err := newSolver(opts)
+	if err != nil {
+		return nil, err
+	}
+	return referenceSolver{s.(*daySolver)}, nil
+}
+
+func (s referenceSolver) seedToLocation() []RangeMap {
+	almanac := s.almanac
+	return []RangeMap{almanac.SeedToSoil, almanac.SoilToFertilizer, almanac.FertilizerToWater, almanac.WaterToLight, almanac.LightToTemperature, almanac.TemperatureToHumidity, almanac.HumidityToLocation}
+}
+
+func (s referenceSolver) Part1() (solver.Answer, error) {
+	values := make([]Range, len(s.almanac.Seeds))
+	for i, seed := range s.almanac.Seeds {
+		values[i] = Range{Start: seed, Length: 1}
+	}
+	for _, rangeMap := range s.seedToLocation() {
+		values = rangeMap.getDestinations(values)
+	}
+	if len(values) == 0 {
+		return solver.Answer{}, errors.New("no seeds")
+	}
+	return solver.Answer{Value: slices.MinFunc(values, func(a, b Range) int { return a.Start - b.Start }).Start}, nil
+}
+
+func (s referenceSolver) Part2() (solver.Answer, error) {
+	seeds := s.almanac.Seeds
+	if len(seeds)%2 != 0 {
+		return solver.Answer{}, fmt.Errorf("odd number of seeds (%v), expected pairs of range start and length", len(seeds))
+	}
+	total := 0
+	for i := 1; i < len(seeds); i += 2 {
+		total += seeds[i]
+	}
+	if total > referenceMaxSeeds {
+		return solver.Answer{}, fmt.Errorf("%w: too many seeds (%v) to map one at a time", solver.ErrTooBig, total)
+	}
+
+	location, found := 0, false
+	for i := 0; i < len(seeds); i += 2 {
+		for seed := seeds[i]; seed < seeds[i]+seeds[i+1]; seed++ {
+			value := seed
+			for _, rangeMap := range s.seedToLocation() {
+				value = rangeMap.getDestination(value)
+			}
+			if !found || value < location {
+				location, found = value, true
+			}
+		}
+	}
+	if !found {
+		return solver.Answer{}, errors.New("no seeds")
+	}
+	return solver.Answer{Value: location}, nil
+}
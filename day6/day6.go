@@ -0,0 +1,189 @@
+package day6
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+type Race struct {
+	Time     int
+	Distance int
+}
+
+func parseNumbersPrefix(line input.Line, prefix string, ignoreSpaces bool) ([]int, error) {
+	if !ignoreSpaces {
+		return line.IntsPrefix(prefix)
+	}
+	rest, err := line.CutPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	rest = rest.TrimSpace()
+	number, err := strconv.Atoi(strings.Join(strings.Fields(rest.Text), ""))
+	if err != nil {
+		return nil, rest.Errorf("invalid number %#v", rest.Text)
+	}
+	return []int{number}, nil
+}
+
+func parseRaces(lines []input.Line, ignoreSpaces bool) ([]Race, error) {
+	if len(lines) > 2 {
+		return nil, lines[2].Errorf("expected 2 lines (times and distances), got %v", len(lines))
+	}
+	if len(lines) != 2 {
+		return nil, fmt.Errorf("expected 2 lines (times and distances), got %v", len(lines))
+	}
+	times, err := parseNumbersPrefix(lines[0], "Time:", ignoreSpaces)
+	if err != nil {
+		return nil, err
+	}
+	distances, err := parseNumbersPrefix(lines[1], "Distance:", ignoreSpaces)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(times) != len(distances) {
+		return nil, lines[1].Errorf("times (%#v) and distances (%#v) lengths don't match", times, distances)
+	}
+
+	output := make([]Race, len(times))
+	for i := 0; i < len(times); i++ {
+		output[i] = Race{Time: times[i], Distance: distances[i]}
+	}
+	return output, nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 6, New: newSolver, Generate: generate, MaxSize: maxSize, Reference: newReferenceSolver})
+}
+
+type daySolver struct {
+	log   *slog.Logger
+	races []Race
+	// joinedRaces holds the single race read by ignoring the spaces between digits
+	joinedRaces []Race
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{log: opts.Logger()}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return err
+	}
+	races, err := parseRaces(lines, false)
+	if err != nil {
+		return err
+	}
+	joinedRaces, err := parseRaces(lines, true)
+	if err != nil {
+		return err
+	}
+	s.races = races
+	s.joinedRaces = joinedRaces
+	return nil
+}
+
+func solveQuadratic(a float64, b float64, c float64) (float64, float64, bool) {
+	sqrtIn := b*b - 4*a*c
+	if sqrtIn < 0 {
+		return 0, 0, false
+	}
+	sqrt := math.Sqrt(sqrtIn)
+	a2 := a * 2
+	// fmt.Printf("a:%v b:%v c:%v sqrt:%v a2:%v\n", a, b, c, sqrt, a2)
+	minSolution := (-b - sqrt) / a2
+	maxSolution := (-b + sqrt) / a2
+	if minSolution > maxSolution {
+		minSolution, maxSolution = maxSolution, minSolution
+	}
+	return minSolution, maxSolution, true
+}
+
+func getWinButtonTimes(race Race) (min int, max int, found bool) {
+	minSolution, maxSolution, found := solveQuadratic(-1, float64(race.Time), -float64(race.Distance))
+	// fmt.Printf("minSolution: %v, maxSolution: %v\n", minSolution, maxSolution)
+	if !found {
+		return 0, 0, false
+	}
+	min, max = int(math.Ceil(minSolution)), int(math.Floor(maxSolution))
+	// The square root may be off by a little for long races, and equalling the
+	// record doesn't win, so nudge the bounds onto the winning button times
+	wins := func(buttonTime int) bool {
+		return buttonTime*(race.Time-buttonTime) > race.Distance
+	}
+	for min > 0 && wins(min-1) {
+		min--
+	}
+	for max < race.Time && wins(max+1) {
+		max++
+	}
+	for min <= max && !wins(min) {
+		min++
+	}
+	for max >= min && !wins(max) {
+		max--
+	}
+	return min, max, min <= max
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	return solver.Answer{Value: countWaysToWin(s.log, s.races)}, nil
+}
+
+// countWaysToWin tries every button time for every race, returning the product
+// of the number of ways to win each race.
+func countWaysToWin(log *slog.Logger, races []Race) int {
+	marginOfError := 1
+	log.Debug("races", "races", races)
+	for raceI, race := range races {
+		numWaysToWin := 0
+		for buttonTime := 0; buttonTime <= race.Time; buttonTime++ {
+			if canWinRace(log, raceI, race, buttonTime) {
+				numWaysToWin++
+			}
+		}
+		log.Debug("ways to win", "race", raceI, "ways", numWaysToWin)
+		marginOfError *= numWaysToWin
+	}
+	return marginOfError
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	return solver.Answer{Value: countWaysToWinQuadratic(s.log, s.joinedRaces)}, nil
+}
+
+// countWaysToWinQuadratic is like countWaysToWin, but solves for the range of
+// winning button times instead of trying each one.
+func countWaysToWinQuadratic(log *slog.Logger, races []Race) int {
+	marginOfError := 1
+	log.Debug("races", "races", races)
+	for raceI, race := range races {
+		numWaysToWin := 0
+		min, max, found := getWinButtonTimes(race)
+		if found {
+			numWaysToWin = max - min + 1
+		}
+		log.Debug("ways to win", "race", raceI, "ways", numWaysToWin, "min", min, "max", max)
+		marginOfError *= numWaysToWin
+	}
+	return marginOfError
+}
+
+func canWinRace(log *slog.Logger, raceI int, race Race, buttonTime int) bool {
+	speed := buttonTime
+	speedTime := race.Time - buttonTime
+	distance := speed * speedTime
+	canWin := distance > race.Distance
+	log.Debug("button", "race", raceI, "button", buttonTime, "speedTime", speedTime, "speed", speed, "distance", distance, "win", canWin)
+	return canWin
+}
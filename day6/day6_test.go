@@ -0,0 +1,65 @@
+package day6
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+func TestParseRaces(t *testing.T) {
+	lines, err := input.Lines(strings.NewReader("Time:      7  15   30\nDistance:  9  40  200\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	races, err := parseRaces(lines, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(races) != 3 || races[2] != (Race{30, 200}) {
+		t.Errorf("got %v, expected 3 races ending with {30 200}", races)
+	}
+	joined, err := parseRaces(lines, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(joined) != 1 || joined[0] != (Race{71530, 940200}) {
+		t.Errorf("got %v, expected [{71530 940200}]", joined)
+	}
+
+	lines, err = input.Lines(strings.NewReader("Time: 7 15\nDistance: 9\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = parseRaces(lines, false)
+	var parseErr *input.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 {
+		t.Errorf("got %v, expected a *input.ParseError on line 2", err)
+	}
+}
+
+func TestCountWaysToWin(t *testing.T) {
+	log := solver.Options{}.Logger()
+	tests := []struct {
+		race Race
+		ways int
+	}{
+		{Race{7, 9}, 4},
+		{Race{15, 40}, 8},
+		{Race{30, 200}, 9},
+		// Button times of 34 and 54 equal the record, which doesn't win
+		{Race{88, 1836}, 19},
+		{Race{4, 4}, 0},
+		{Race{3, 100}, 0},
+	}
+	for _, test := range tests {
+		if ways := countWaysToWin(log, []Race{test.race}); ways != test.ways {
+			t.Errorf("countWaysToWin(%v): got %v, expected %v", test.race, ways, test.ways)
+		}
+		if ways := countWaysToWinQuadratic(log, []Race{test.race}); ways != test.ways {
+			t.Errorf("countWaysToWinQuadratic(%v): got %v, expected %v", test.race, ways, test.ways)
+		}
+	}
+}
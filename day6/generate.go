@@ -0,0 +1,32 @@
+package day6
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// maxSize is the most races generated, so that the numbers joined for part 2 fit in an int
+const maxSize = 4
+
+// generate returns size random races (maxSize by default). Every race can be won.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 || size > maxSize {
+		size = maxSize
+	}
+	races := make([]Race, size)
+	for i := range races {
+		time := 10 + rng.Intn(90)
+		best := (time / 2) * (time - time/2)
+		races[i] = Race{Time: time, Distance: best/3 + rng.Intn(best-best/3)}
+	}
+
+	var times, distances strings.Builder
+	times.WriteString("Time:     ")
+	distances.WriteString("Distance: ")
+	for _, race := range races {
+		fmt.Fprintf(&times, " %6v", race.Time)
+		fmt.Fprintf(&distances, " %6v", race.Distance)
+	}
+	return times.String() + "\n" + distances.String() + "\n"
+}
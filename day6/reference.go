@@ -0,0 +1,37 @@
+package day6
+
+import (
+	"fmt"
+
+	"aoc/solver"
+)
+
+// referenceMaxTime bounds the race times the reference solver tries every button time for
+const referenceMaxTime = 10000000
+
+// referenceSolver swaps the methods of the two parts, solving the quadratic
+// for part 1 and trying every button time for part 2.
+type referenceSolver struct {
+	*daySolver
+}
+
+func newReferenceSolver(opts solver.Options) (solver.Solver, error) {
+	s, err := newSolver(opts)
+	if err != nil {
+		return nil, err
+	}
+	return referenceSolver{s.(*daySolver)}, nil
+}
+
+func (s referenceSolver) Part1() (solver.Answer, error) {
+	return solver.Answer{Value: countWaysToWinQuadratic(s.log, s.races)}, nil
+}
+
+func (s referenceSolver) Part2() (solver.Answer, error) {
+	for _, race := range s.joinedRaces {
+		if race.Time > referenceMaxTime {
+			return solver.Answer{}, fmt.Errorf("%w: race time %v too long to try every button time", solver.ErrTooBig, race.Time)
+		}
+	}
+	return solver.Answer{Value: countWaysToWin(s.log, s.joinedRaces)}, nil
+}
@@ -1,15 +1,15 @@
-package main
+package day7
 
 import (
-	"bufio"
-	"errors"
 	"fmt"
-	"os"
+	"io"
+	"log/slog"
 	"slices"
-	"strconv"
-	"strings"
 
 	t "github.com/barweiss/go-tuple"
+
+	"aoc/input"
+	"aoc/solver"
 )
 
 type Card int
@@ -195,21 +195,21 @@ func (h Hand) String() string {
 	return fmt.Sprintf("%v%v%v%v%v (%v)", h.Cards[0], h.Cards[1], h.Cards[2], h.Cards[3], h.Cards[4], h.HandType)
 }
 
-func parseHand(handString string, jackIsJoker bool) (Hand, error) {
-	handString = strings.TrimSpace(handString)
+func parseHand(handLine input.Line, jackIsJoker bool) (Hand, error) {
+	handLine = handLine.TrimSpace()
 	var hand []Card
-	for _, r := range handString {
+	for i, r := range handLine.Text {
 		card, err := parseCard(r)
 		if err != nil {
-			return Hand{}, err
+			return Hand{}, handLine.From(i).Error(err)
 		}
 		hand = append(hand, card)
 		if len(hand) > 5 {
-			return Hand{}, errors.New("hand too large")
+			return Hand{}, handLine.From(i).Errorf("hand too large")
 		}
 	}
 	if len(hand) != 5 {
-		return Hand{}, errors.New("hand not 5 cards")
+		return Hand{}, handLine.Errorf("hand not 5 cards")
 	}
 	return createHand([5]Card{hand[0], hand[1], hand[2], hand[3], hand[4]}, jackIsJoker), nil
 }
@@ -219,64 +219,51 @@ type HandBid struct {
 	Bid  int
 }
 
-func getInput(path string, jackIsJoker bool) ([]HandBid, error) {
-	file, err := os.Open(path)
+func parseInput(r io.Reader, jackIsJoker bool) ([]HandBid, error) {
+	lines, err := input.Lines(r)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	var handBids []HandBid
-	for scanner.Scan() {
-		line := scanner.Text()
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		lineSplit := strings.Fields(line)
+	handBids := make([]HandBid, 0, len(lines))
+	for _, line := range lines {
+		lineSplit := line.Fields()
 		if len(lineSplit) != 2 {
-			return nil, fmt.Errorf("invalid line (too many fields): %+v", line)
+			return nil, line.Errorf("invalid line (expected 2 fields, got %v)", len(lineSplit))
 		}
 		hand, err := parseHand(lineSplit[0], jackIsJoker)
 		if err != nil {
 			return nil, err
 		}
-		bid, err := strconv.Atoi(lineSplit[1])
+		bid, err := lineSplit[1].Atoi()
 		if err != nil {
 			return nil, err
 		}
 		handBids = append(handBids, HandBid{hand, bid})
 	}
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
 	return handBids, nil
 }
 
-type Args struct {
-	Part      int
-	InputPath string
+func init() {
+	solver.Register(solver.Day{Number: 7, New: newSolver, Generate: generate})
 }
 
-func parseArgs() (Args, error) {
-	switch len(os.Args) {
-	case 3:
-		break
-	default:
-		return Args{}, fmt.Errorf("invalid arguments. Expected %v <part> <inputPath>", os.Args[0])
-	}
-	var part int
-	switch os.Args[1] {
-	case "1":
-		part = 1
-	case "2":
-		part = 2
-	default:
-		return Args{}, fmt.Errorf("invalid part number %#v. Expected 1/2", os.Args[1])
+type daySolver struct {
+	log      *slog.Logger
+	handBids []HandBid
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{log: opts.Logger()}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	handBids, err := parseInput(r, false)
+	if err != nil {
+		return err
 	}
-	return Args{Part: part, InputPath: os.Args[2]}, nil
+	s.handBids = handBids
+	return nil
 }
 
 func getSortCardFunc(jackIsJoker bool) func(Card, Card) int {
@@ -321,40 +308,28 @@ func getSortHandBidFunc(jackIsJoker bool) func(HandBid, HandBid) int {
 	}
 }
 
-func run() error {
-	args, err := parseArgs()
-	if err != nil {
-		return err
-	}
-	fmt.Printf("Args: %+v\n", args)
+func (s *daySolver) Part1() (solver.Answer, error) {
+	handBids := slices.Clone(s.handBids)
+	return solver.Answer{Value: getTotalWinnings(s.log, handBids, false)}, nil
+}
 
-	jackIsJoker := args.Part == 2
-	handBids, err := getInput(args.InputPath, jackIsJoker)
-	if err != nil {
-		return err
+func (s *daySolver) Part2() (solver.Answer, error) {
+	// Hand types change when jacks are jokers, so rebuild the hands
+	handBids := make([]HandBid, len(s.handBids))
+	for i, handBid := range s.handBids {
+		handBids[i] = HandBid{Hand: createHand(handBid.Hand.Cards, true), Bid: handBid.Bid}
 	}
-
-	fmt.Printf("Total winnings: %v\n", getTotalWinnings(handBids, jackIsJoker))
-
-	return nil
+	return solver.Answer{Value: getTotalWinnings(s.log, handBids, true)}, nil
 }
 
-func getTotalWinnings(handBids []HandBid, jackIsJoker bool) int {
+func getTotalWinnings(log *slog.Logger, handBids []HandBid, jackIsJoker bool) int {
 	slices.SortStableFunc(handBids, getSortHandBidFunc(jackIsJoker))
 	totalWinnings := 0
 	for i, handBid := range handBids {
 		rank := i + 1
 		winnings := handBid.Bid * rank
 		totalWinnings += winnings
-		fmt.Printf("Rank %v: %+v wins %v\n", rank, handBid, winnings)
+		log.Debug("rank", "rank", rank, "hand", handBid.Hand, "bid", handBid.Bid, "winnings", winnings)
 	}
 	return totalWinnings
 }
-
-func main() {
-	err := run()
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
-	}
-}
@@ -0,0 +1,20 @@
+package day7
+
+import (
+	"errors"
+	"testing"
+
+	"aoc/input"
+)
+
+func FuzzParseHand(f *testing.F) {
+	f.Add("32T3K", false)
+	f.Add("KTJJT", true)
+	f.Fuzz(func(t *testing.T, text string, jackIsJoker bool) {
+		_, err := parseHand(input.Line{Number: 1, Text: text}, jackIsJoker)
+		var parseErr *input.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			t.Errorf("got %v, expected a *input.ParseError", err)
+		}
+	})
+}
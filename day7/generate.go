@@ -0,0 +1,34 @@
+package day7
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// generate returns size random hands (1000 by default) with bids up to 1000.
+// Cards are drawn from a few of the labels at a time, so that every hand type
+// turns up.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 {
+		size = 1000
+	}
+	handBids := make([]HandBid, size)
+	for i := range handBids {
+		labels := rng.Perm(int(CardA-Card2) + 1)[:1+rng.Intn(5)]
+		var cards [5]Card
+		for j := range cards {
+			cards[j] = Card2 + Card(labels[rng.Intn(len(labels))])
+		}
+		handBids[i] = HandBid{Hand: createHand(cards, false), Bid: 1 + rng.Intn(1000)}
+	}
+
+	var sb strings.Builder
+	for _, handBid := range handBids {
+		for _, card := range handBid.Hand.Cards {
+			sb.WriteString(card.String())
+		}
+		fmt.Fprintf(&sb, " %v\n", handBid.Bid)
+	}
+	return sb.String()
+}
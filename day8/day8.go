@@ -0,0 +1,338 @@
+package day8
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	t "github.com/barweiss/go-tuple"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+type Node struct {
+	Name  string
+	Left  *Node
+	Right *Node
+}
+
+func (n *Node) getNextNode(direction Direction) *Node {
+	if direction == DirectionLeft {
+		return n.Left
+	} else {
+		return n.Right
+	}
+}
+
+type Network struct {
+	Nodes  map[string]*Node
+	Starts []*Node
+	Ends   []*Node
+}
+
+type Direction bool
+
+const (
+	DirectionLeft  = false
+	DirectionRight = true
+)
+
+func (d Direction) String() string {
+	switch d {
+	case DirectionLeft:
+		return "L"
+	case DirectionRight:
+		return "R"
+	}
+	panic("unreachable")
+}
+
+type Map struct {
+	// false is left, true is right
+	Directions []Direction
+	Network    Network
+}
+
+func parseDirections(directionsLine input.Line) ([]Direction, error) {
+	result := make([]Direction, 0, len(directionsLine.Text))
+	for i, r := range directionsLine.Text {
+		switch r {
+		case 'L':
+			result = append(result, DirectionLeft)
+		case 'R':
+			result = append(result, DirectionRight)
+		default:
+			return nil, directionsLine.From(i).Errorf("invalid direction %q", r)
+		}
+	}
+	return result, nil
+}
+
+func parseInput(r io.Reader) (Map, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return Map{}, err
+	}
+
+	nodes := make(map[string]*Node)
+	nodeDirections := make(map[string]t.T2[input.Line, input.Line])
+
+	if len(lines) == 0 {
+		return Map{}, fmt.Errorf("no directions input")
+	}
+	directions, err := parseDirections(lines[0])
+	if err != nil {
+		return Map{}, err
+	}
+
+	for _, line := range lines[1:] {
+		lineSplit := line.Split("=")
+		if len(lineSplit) != 2 {
+			return Map{}, line.Errorf("invalid line (expected 1 '=')")
+		}
+
+		nodeName := lineSplit[0].TrimSpace().Text
+		nodes[nodeName] = &Node{Name: nodeName}
+		leftRight, err := lineSplit[1].TrimSpace().CutPrefix("(")
+		if err != nil {
+			return Map{}, err
+		}
+		if !strings.HasSuffix(leftRight.Text, ")") {
+			return Map{}, leftRight.From(len(leftRight.Text)).Errorf("expected \")\"")
+		}
+		leftRight = leftRight.Slice(0, len(leftRight.Text)-1)
+
+		leftRightSplit := leftRight.Split(",")
+		if len(leftRightSplit) != 2 {
+			return Map{}, leftRight.Errorf("invalid line (expected 1 ',')")
+		}
+
+		nodeDirections[nodeName] = t.New2(leftRightSplit[0].TrimSpace(), leftRightSplit[1].TrimSpace())
+	}
+
+	for nodeName, nodeDirectionsTuple := range nodeDirections {
+		node := nodes[nodeName]
+		node.Left, err = getNode(nodes, nodeDirectionsTuple.V1.Text)
+		if err != nil {
+			return Map{}, nodeDirectionsTuple.V1.Error(err)
+		}
+		node.Right, err = getNode(nodes, nodeDirectionsTuple.V2.Text)
+		if err != nil {
+			return Map{}, nodeDirectionsTuple.V2.Error(err)
+		}
+	}
+
+	return Map{Network: Network{Nodes: nodes}, Directions: directions}, nil
+}
+
+func getNode(nodes map[string]*Node, nodeName string) (*Node, error) {
+	node := nodes[nodeName]
+	if node == nil {
+		return nil, fmt.Errorf("unknown node %+v", nodeName)
+	}
+	return node, nil
+}
+
+// withRouters returns a copy of the network with its start and end nodes chosen.
+// A single router goes from AAA to ZZZ, while multiple routers start on every
+// node ending in A and finish on every node ending in Z.
+func (n Network) withRouters(multipleRouters bool) (Network, error) {
+	var startNodes []*Node
+	var endNodes []*Node
+	if multipleRouters {
+		for nodeName, node := range n.Nodes {
+			if strings.HasSuffix(nodeName, "A") {
+				startNodes = append(startNodes, node)
+			}
+			if strings.HasSuffix(nodeName, "Z") {
+				endNodes = append(endNodes, node)
+			}
+		}
+		if len(startNodes) == 0 {
+			return Network{}, errors.New("no start nodes")
+		}
+		slices.SortFunc(startNodes, func(a, b *Node) int { return strings.Compare(a.Name, b.Name) })
+		if len(endNodes) == 0 {
+			return Network{}, errors.New("no end nodes")
+		}
+	} else {
+		startNode, err := getNode(n.Nodes, "AAA")
+		if err != nil {
+			return Network{}, err
+		}
+		endNode, err := getNode(n.Nodes, "ZZZ")
+		if err != nil {
+			return Network{}, err
+		}
+		startNodes = []*Node{startNode}
+		endNodes = []*Node{endNode}
+	}
+	return Network{Nodes: n.Nodes, Starts: startNodes, Ends: endNodes}, nil
+}
+
+func (n Network) isEndNode(node *Node) bool {
+	for _, endNode := range n.Ends {
+		if node == endNode {
+			return true
+		}
+	}
+	return false
+}
+
+func (n Network) areAllEndNodes(nodes []*Node) bool {
+	for _, node := range nodes {
+		if !n.isEndNode(node) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 8, New: newSolver, Generate: generate, MaxSize: maxSize, Reference: newReferenceSolver})
+}
+
+type daySolver struct {
+	log      *slog.Logger
+	inputMap Map
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{log: opts.Logger()}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	inputMap, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	if solver.DebugEnabled(s.log) {
+		var sb strings.Builder
+		for _, direction := range inputMap.Directions {
+			sb.WriteString(direction.String())
+		}
+		s.log.Debug("directions", "count", len(inputMap.Directions), "directions", sb.String())
+	}
+	s.inputMap = inputMap
+	return nil
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	return s.solve(false)
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	return s.solve(true)
+}
+
+func (s *daySolver) solve(multipleRouters bool) (solver.Answer, error) {
+	network, err := s.inputMap.Network.withRouters(multipleRouters)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	inputMap := Map{Directions: s.inputMap.Directions, Network: network}
+	totalSteps, periodicities, aligned := getTotalStepsAnalytical(s.log, inputMap)
+	if aligned {
+		return solver.Answer{Value: totalSteps, Details: periodicities}, nil
+	}
+
+	// Once every ghost is in its cycle, they are all back where they were
+	// after the LCM of the cycle lengths, so if they aren't all on end nodes
+	// at once by then, they never will be
+	maxSteps, period := 0, 1
+	for i, periodicity := range periodicities {
+		if len(periodicity.EndSteps) == 0 {
+			return solver.Answer{}, fmt.Errorf("ghost %v from %v never reaches an end node", i, periodicity.Start)
+		}
+		maxSteps = max(maxSteps, periodicity.Offset)
+		period = lcm(period, periodicity.Length)
+	}
+	s.log.Debug("cycles don't line up with their end nodes, following the ghosts", "maxSteps", maxSteps+period)
+	totalSteps, found := getTotalSteps(s.log, inputMap, maxSteps+period)
+	if !found {
+		return solver.Answer{}, errors.New("the ghosts are never all on end nodes at once")
+	}
+	return solver.Answer{Value: totalSteps, Details: periodicities}, nil
+}
+
+func gcd(a, b int) int {
+	if b == 0 {
+		return a
+	}
+	return gcd(b, a%b)
+}
+
+func lcm(a, b int) int {
+	return a / gcd(a, b) * b
+}
+
+// Periodicity describes the cycle a ghost ends up in: after Offset steps it
+// repeats every Length steps, passing through end nodes at EndSteps.
+type Periodicity struct {
+	Start    string `json:"start"`
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+	EndSteps []int  `json:"endSteps"`
+}
+
+// getTotalStepsAnalytical works out the steps from the ghosts' cycles, as the
+// LCM of their lengths. This is only right when each ghost passes one end node
+// in its cycle, at a step equal to the cycle length, so that it is on an end
+// node exactly at the multiples of the length, which it reports with aligned.
+func getTotalStepsAnalytical(log *slog.Logger, inputMap Map) (totalSteps int, periodicities []Periodicity, aligned bool) {
+	totalSteps, aligned = 1, true
+	periodicities = make([]Periodicity, 0, len(inputMap.Network.Starts))
+	for i, node := range inputMap.Network.Starts {
+		offset, length, endSteps := getPeriodicity(inputMap, node)
+		log.Debug("ghost", "ghost", i, "start", node.Name, "offset", offset, "length", length, "lengthPerDirections", length/len(inputMap.Directions), "endSteps", endSteps)
+		periodicities = append(periodicities, Periodicity{Start: node.Name, Offset: offset, Length: length, EndSteps: endSteps})
+		if len(endSteps) != 1 || endSteps[0] != length || offset > length {
+			aligned = false
+		}
+		totalSteps = lcm(totalSteps, length)
+	}
+	return totalSteps, periodicities, aligned
+}
+
+// getTotalSteps moves every ghost from its start until they are all on end
+// nodes at once, giving up after maxSteps.
+func getTotalSteps(log *slog.Logger, inputMap Map, maxSteps int) (int, bool) {
+	debug := solver.DebugEnabled(log)
+	step := 0
+	for nodes := slices.Clone(inputMap.Network.Starts); !inputMap.Network.areAllEndNodes(nodes); step++ {
+		if step == maxSteps {
+			return 0, false
+		}
+		direction := inputMap.Directions[step%len(inputMap.Directions)]
+		for i, node := range nodes {
+			nodes[i] = node.getNextNode(direction)
+			if debug {
+				log.Debug("step", "step", step, "direction", direction, "ghost", i, "from", node.Name, "to", nodes[i].Name)
+			}
+		}
+	}
+	return step, true
+}
+
+func getPeriodicity(inputMap Map, startNode *Node) (offset int, length int, endSteps []int) {
+	nodeDirectionIndexSteps := make(map[t.T2[*Node, int]]int)
+	for step, node := 0, startNode; ; step++ {
+		directionIndex := step % len(inputMap.Directions)
+		nodeDirectionIndex := t.New2(node, directionIndex)
+		if nodeDirectionIndexStep, found := nodeDirectionIndexSteps[nodeDirectionIndex]; found {
+			// Periodicity found
+			offset = nodeDirectionIndexStep
+			length = step - nodeDirectionIndexStep
+			return
+		}
+		if inputMap.Network.isEndNode(node) {
+			endSteps = append(endSteps, step)
+		}
+		nodeDirectionIndexSteps[nodeDirectionIndex] = step
+		node = node.getNextNode(inputMap.Directions[directionIndex])
+	}
+}
@@ -0,0 +1,58 @@
+package day8
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"aoc/solver"
+)
+
+func FuzzParseInput(f *testing.F) {
+	f.Add("RL\n\nAAA = (BBB, CCC)\nBBB = (DDD, EEE)\nCCC = (ZZZ, GGG)\nDDD = (DDD, DDD)\nEEE = (EEE, EEE)\nGGG = (GGG, GGG)\nZZZ = (ZZZ, ZZZ)\n")
+	f.Add("LLR\n\nAAA = (BBB, BBB)\nBBB = (AAA, ZZZ)\nZZZ = (ZZZ, ZZZ)\n")
+	f.Fuzz(func(t *testing.T, text string) {
+		m, err := parseInput(strings.NewReader(text))
+		if err != nil {
+			return
+		}
+		m.Network.withRouters(false)
+		m.Network.withRouters(true)
+	})
+}
+
+// TestGeneratedCycles checks that the generated maps include ones where the
+// LCM of the cycle lengths is the wrong answer, so that differential testing
+// catches solvers relying on it, and that the solver gets them right.
+func TestGeneratedCycles(t *testing.T) {
+	log := solver.Options{}.Logger()
+	wrongLCMs := 0
+	for seed := int64(1); seed <= 50; seed++ {
+		text := generate(rand.New(rand.NewSource(seed)), 1+int(seed)%3)
+		s, err := newSolver(solver.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Parse(strings.NewReader(text)); err != nil {
+			t.Fatalf("seed %v: %v", seed, err)
+		}
+		network, err := s.(*daySolver).inputMap.Network.withRouters(true)
+		if err != nil {
+			t.Fatalf("seed %v: %v", seed, err)
+		}
+		inputMap := Map{Directions: s.(*daySolver).inputMap.Directions, Network: network}
+		expected, found := getTotalSteps(log, inputMap, referenceMaxSteps)
+		if !found {
+			continue
+		}
+		if lcm, _, _ := getTotalStepsAnalytical(log, inputMap); lcm != expected {
+			wrongLCMs++
+		}
+		if answer, err := s.Part2(); err != nil || answer.Value != expected {
+			t.Errorf("seed %v: got %v (%v), expected %v", seed, answer.Value, err, expected)
+		}
+	}
+	if wrongLCMs == 0 {
+		t.Errorf("the LCM of the cycle lengths was right for every generated map")
+	}
+}
@@ -0,0 +1,141 @@
+package day8
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// maxSize is the most ghosts generated, so that part 2's answer fits in an int
+const maxSize = 6
+
+// generate returns a random map with size ghosts (maxSize by default), and
+// ghost 0 going from AAA to ZZZ.
+// Half of the maps are like the real ones, where each ghost ends up in a cycle
+// that passes its end node once, after a whole number of passes through the
+// directions. The others have short cycles, entered after a few steps, with
+// end nodes anywhere, including before the cycle and several in it, and one
+// step at which every ghost is on an end node.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 || size > maxSize {
+		size = maxSize
+	}
+	directions := make([]Direction, 2+rng.Intn(20))
+	for i := range directions {
+		directions[i] = rng.Intn(2) == 0
+	}
+
+	used := make(map[string]bool)
+	newName := func(suffix byte) string {
+		for {
+			name := string([]byte{byte('A' + rng.Intn(26)), byte('A' + rng.Intn(26)), suffix})
+			if !used[name] {
+				used[name] = true
+				return name
+			}
+		}
+	}
+	newMiddleName := func() string {
+		// Any suffix but A or Z
+		return newName(byte('B' + rng.Intn(24)))
+	}
+	used["AAA"], used["ZZZ"] = true, true
+
+	// Each ghost's path has prefix steps, then loops back to path[prefix] after cycle steps
+	type ghostPath struct {
+		prefix, cycle int
+		// ends are the steps along the path at end nodes
+		ends map[int]bool
+	}
+	paths := make([]ghostPath, size)
+	if rng.Intn(2) == 0 {
+		primes := []int{11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53, 59, 61, 67, 71, 73, 79, 83, 89, 97}
+		for ghost, prime := range rng.Perm(len(primes))[:size] {
+			length := len(directions) * primes[prime]
+			paths[ghost] = ghostPath{prefix: 1, cycle: length, ends: map[int]bool{length: true}}
+		}
+	} else {
+		for ghost := range paths {
+			paths[ghost] = ghostPath{prefix: 1 + rng.Intn(2*len(directions)), cycle: len(directions) * (1 + rng.Intn(3)), ends: make(map[int]bool)}
+		}
+		// Every ghost is on an end node at the same step
+		target := 1 + rng.Intn(4*len(directions))
+		for _, path := range paths {
+			for _, step := range []int{target, 1 + rng.Intn(path.prefix+path.cycle-1), 1 + rng.Intn(path.prefix+path.cycle-1)} {
+				if step >= path.prefix+path.cycle {
+					step = path.prefix + (step-path.prefix)%path.cycle
+				}
+				path.ends[step] = true
+			}
+		}
+	}
+
+	nodes := make(map[string]*Node)
+	var unused []*Node
+	for ghost, ghostPath := range paths {
+		path := make([]*Node, ghostPath.prefix+ghostPath.cycle)
+		// Ghost 0's first end node is ZZZ, and part 1 ignores the others
+		named := false
+		for step := range path {
+			switch {
+			case step == 0 && ghost == 0:
+				path[step] = &Node{Name: "AAA"}
+			case step == 0:
+				path[step] = &Node{Name: newName('A')}
+			case ghostPath.ends[step] && ghost == 0 && !named:
+				path[step] = &Node{Name: "ZZZ"}
+				named = true
+			case ghostPath.ends[step]:
+				path[step] = &Node{Name: newName('Z')}
+			default:
+				path[step] = &Node{Name: newMiddleName()}
+			}
+		}
+		for step, node := range path {
+			next := path[ghostPath.prefix]
+			if step+1 < len(path) {
+				next = path[step+1]
+			}
+			if directions[step%len(directions)] == DirectionLeft {
+				node.Left = next
+			} else {
+				node.Right = next
+			}
+			nodes[node.Name] = node
+		}
+		unused = append(unused, path...)
+	}
+	for i := len(nodes) / 4; i > 0; i-- {
+		node := &Node{Name: newMiddleName()}
+		nodes[node.Name] = node
+		unused = append(unused, node)
+	}
+	// Point the sides that are never taken anywhere
+	for _, node := range unused {
+		if node.Left == nil {
+			node.Left = unused[rng.Intn(len(unused))]
+		}
+		if node.Right == nil {
+			node.Right = unused[rng.Intn(len(unused))]
+		}
+	}
+	m := Map{Directions: directions, Network: Network{Nodes: nodes}}
+
+	var sb strings.Builder
+	for _, direction := range m.Directions {
+		sb.WriteString(direction.String())
+	}
+	sb.WriteString("\n\n")
+	names := make([]string, 0, len(m.Network.Nodes))
+	for name := range m.Network.Nodes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	rng.Shuffle(len(names), func(a, b int) { names[a], names[b] = names[b], names[a] })
+	for _, name := range names {
+		node := m.Network.Nodes[name]
+		fmt.Fprintf(&sb, "%v = (%v, %v)\n", node.Name, node.Left.Name, node.Right.Name)
+	}
+	return sb.String()
+}
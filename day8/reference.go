@@ -0,0 +1,43 @@
+package day8
+
+import (
+	"fmt"
+
+	"aoc/solver"
+)
+
+// referenceMaxSteps bounds the steps simulated by the reference solver
+const referenceMaxSteps = 1000000
+
+// referenceSolver follows the ghosts step by step rather than working out where their cycles line up.
+type referenceSolver struct {
+	*daySolver
+}
+
+func newReferenceSolver(opts solver.Options) (solver.Solver, error) {
+	s, err := newSolver(opts)
+	if err != nil {
+		return nil, err
+	}
+	return referenceSolver{s.(*daySolver)}, nil
+}
+
+func (s referenceSolver) Part1() (solver.Answer, error) {
+	return s.solve(false)
+}
+
+func (s referenceSolver) Part2() (solver.Answer, error) {
+	return s.solve(true)
+}
+
+func (s referenceSolver) solve(multipleRouters bool) (solver.Answer, error) {
+	network, err := s.inputMap.Network.withRouters(multipleRouters)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	steps, found := getTotalSteps(s.log, Map{Directions: s.inputMap.Directions, Network: network}, referenceMaxSteps)
+	if !found {
+		return solver.Answer{}, fmt.Errorf("%w: not all ghosts at end nodes after %v steps", solver.ErrTooBig, referenceMaxSteps)
+	}
+	return solver.Answer{Value: steps}, nil
+}
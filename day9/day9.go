@@ -0,0 +1,111 @@
+package day9
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"aoc/input"
+	"aoc/solver"
+)
+
+func parseInput(r io.Reader) ([][]int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make([][]int, 0, len(lines))
+	for _, line := range lines {
+		history, err := line.Ints()
+		if err != nil {
+			return nil, err
+		}
+		if len(history) == 0 {
+			return nil, line.Errorf("empty history")
+		}
+		histories = append(histories, history)
+	}
+	return histories, nil
+}
+
+func init() {
+	solver.Register(solver.Day{Number: 9, New: newSolver, Generate: generate})
+}
+
+type daySolver struct {
+	log       *slog.Logger
+	histories [][]int
+}
+
+func newSolver(opts solver.Options) (solver.Solver, error) {
+	return &daySolver{log: opts.Logger()}, nil
+}
+
+func (s *daySolver) Parse(r io.Reader) error {
+	histories, err := parseInput(r)
+	if err != nil {
+		return err
+	}
+	s.histories = histories
+	return nil
+}
+
+func (s *daySolver) Part1() (solver.Answer, error) {
+	sum, err := getExtrapolatedValueSum(s.log, s.histories, false)
+	return solver.Answer{Value: sum}, err
+}
+
+func (s *daySolver) Part2() (solver.Answer, error) {
+	sum, err := getExtrapolatedValueSum(s.log, s.histories, true)
+	return solver.Answer{Value: sum}, err
+}
+
+// getExtrapolatedValueSum returns the sum of the next values of the histories,
+// or of the previous values if backwards. Each history must have enough values
+// for its differences to reach all zeros.
+func getExtrapolatedValueSum(log *slog.Logger, histories [][]int, backwards bool) (int, error) {
+	extrapolatedValueSum := 0
+	for hi, history := range histories {
+		diffs := [][]int{history}
+		for i := 1; ; i++ {
+			prevDiff := diffs[i-1]
+			if len(prevDiff) == 1 {
+				return 0, fmt.Errorf("history %v: the differences run out of values before reaching all zeros", hi+1)
+			}
+			diff := make([]int, len(prevDiff)-1)
+			isZero := true
+			for j := 0; j < len(diff); j++ {
+				diff[j] = prevDiff[j+1] - prevDiff[j]
+				if diff[j] != 0 {
+					isZero = false
+				}
+			}
+			diffs = append(diffs, diff)
+			if isZero {
+				break
+			}
+		}
+
+		for i := len(diffs) - 2; i >= 0; i-- {
+			currentDiff := diffs[i]
+			prevDiff := diffs[i+1]
+			if !backwards {
+				currentDiff = append(currentDiff, currentDiff[len(currentDiff)-1]+prevDiff[len(prevDiff)-1])
+			} else {
+				currentDiff = append([]int{currentDiff[0] - prevDiff[0]}, currentDiff...)
+			}
+			diffs[i] = currentDiff
+		}
+
+		for i := 0; i < len(diffs); i++ {
+			log.Debug("diff", "history", hi, "depth", i, "values", diffs[i])
+		}
+		if !backwards {
+			extrapolatedValueSum += diffs[0][len(diffs[0])-1]
+		} else {
+			extrapolatedValueSum += diffs[0][0]
+		}
+	}
+	return extrapolatedValueSum, nil
+}
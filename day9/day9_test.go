@@ -0,0 +1,49 @@
+package day9
+
+import (
+	"strings"
+	"testing"
+
+	"aoc/solver"
+)
+
+func TestExtrapolate(t *testing.T) {
+	histories, err := parseInput(strings.NewReader("0 3 6 9 12 15\n1 3 6 10 15 21\n10 13 16 21 30 45\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := solver.Options{}.Logger()
+	tests := []struct {
+		history  int
+		forwards int
+		backward int
+	}{
+		{0, 18, -3},
+		{1, 28, 0},
+		{2, 68, 5},
+	}
+	for _, test := range tests {
+		history := [][]int{histories[test.history]}
+		if got, err := getExtrapolatedValueSum(log, history, false); err != nil || got != test.forwards {
+			t.Errorf("history %v forwards: got %v (%v), expected %v", test.history, got, err, test.forwards)
+		}
+		if got, err := getExtrapolatedValueSum(log, history, true); err != nil || got != test.backward {
+			t.Errorf("history %v backwards: got %v (%v), expected %v", test.history, got, err, test.backward)
+		}
+	}
+	if got, err := getExtrapolatedValueSum(log, histories, false); err != nil || got != 114 {
+		t.Errorf("got sum %v (%v), expected 114", got, err)
+	}
+}
+
+func TestExtrapolateTooShort(t *testing.T) {
+	log := solver.Options{}.Logger()
+	for _, history := range [][]int{{5}, {1, 2, 4}} {
+		if got, err := getExtrapolatedValueSum(log, [][]int{history}, false); err == nil {
+			t.Errorf("%v: got %v, expected an error", history, got)
+		}
+	}
+	if got, err := getExtrapolatedValueSum(log, [][]int{{5, 5}}, true); err != nil || got != 5 {
+		t.Errorf("[5 5]: got %v (%v), expected 5", got, err)
+	}
+}
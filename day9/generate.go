@@ -0,0 +1,43 @@
+package day9
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// generate returns size random histories (200 by default) of 21 values, each
+// sampled from a random polynomial of degree at most 5 so that extrapolating
+// it is exact.
+func generate(rng *rand.Rand, size int) string {
+	if size <= 0 {
+		size = 200
+	}
+	histories := make([][]int, size)
+	for i := range histories {
+		coefficients := make([]int, 1+rng.Intn(6))
+		for j := range coefficients {
+			coefficients[j] = rng.Intn(21) - 10
+		}
+		for x := 0; x < 21; x++ {
+			// Horner's method
+			value := 0
+			for j := len(coefficients) - 1; j >= 0; j-- {
+				value = value*x + coefficients[j]
+			}
+			histories[i] = append(histories[i], value)
+		}
+	}
+
+	var sb strings.Builder
+	for _, history := range histories {
+		for i, value := range history {
+			if i != 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(strconv.Itoa(value))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
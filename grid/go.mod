@@ -0,0 +1,3 @@
+module aoc/grid
+
+go 1.21.1
@@ -0,0 +1,223 @@
+// Package grid provides a generic rectangular 2D grid shared by the grid-based days.
+//
+// Rows are views of the grid's cells, but as cells are stored in row-major
+// order, columns, transposes and rotations are copies.
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"aoc/input"
+)
+
+// Grid is a rectangular grid of cells stored in row-major order.
+type Grid[T any] struct {
+	Width  int
+	Height int
+	cells  []T
+}
+
+func New[T any](width int, height int) Grid[T] {
+	return Grid[T]{Width: width, Height: height, cells: make([]T, width*height)}
+}
+
+// FromRows copies rows into a new grid, checking that it is non-empty and rectangular.
+func FromRows[T any](rows [][]T) (Grid[T], error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return Grid[T]{}, errors.New("empty grid")
+	}
+	g := New[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		if len(row) != g.Width {
+			return Grid[T]{}, fmt.Errorf("jagged grid (line %v is length %v, line 1 is length %v)", y+1, len(row), g.Width)
+		}
+		copy(g.Row(y), row)
+	}
+	return g, nil
+}
+
+// Parse converts lines of input into a grid of cells using f, checking that it
+// is non-empty and rectangular. Errors point at the offending cell.
+func Parse[T any](lines []input.Line, f func(p Vec2, r rune) (T, error)) (Grid[T], error) {
+	if len(lines) == 0 || lines[0].Text == "" {
+		return Grid[T]{}, errors.New("empty grid")
+	}
+	width := utf8.RuneCountInString(lines[0].Text)
+	g := New[T](width, len(lines))
+	for y, line := range lines {
+		x := 0
+		for i, r := range line.Text {
+			if x == width {
+				return Grid[T]{}, line.From(i).Errorf("jagged grid (line is longer than the first line, which is length %v)", width)
+			}
+			p := Vec2{x, y}
+			v, err := f(p, r)
+			if err != nil {
+				return Grid[T]{}, line.From(i).Error(err)
+			}
+			g.Set(p, v)
+			x++
+		}
+		if x != width {
+			return Grid[T]{}, line.From(len(line.Text)).Errorf("jagged grid (line is length %v, the first line is length %v)", x, width)
+		}
+	}
+	return g, nil
+}
+
+func (g Grid[T]) Size() Vec2 {
+	return Vec2{g.Width, g.Height}
+}
+
+func (g Grid[T]) InBounds(p Vec2) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.Width && p.Y < g.Height
+}
+
+// Get returns the cell at p, which must be in bounds.
+func (g Grid[T]) Get(p Vec2) T {
+	return g.cells[p.Y*g.Width+p.X]
+}
+
+// Lookup returns the cell at p, or false if p is out of bounds.
+func (g Grid[T]) Lookup(p Vec2) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.Get(p), true
+}
+
+func (g Grid[T]) Set(p Vec2, v T) {
+	g.cells[p.Y*g.Width+p.X] = v
+}
+
+// Fill sets every cell to v.
+func (g Grid[T]) Fill(v T) {
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+}
+
+// Row returns a view of row y; writes to it modify the grid.
+func (g Grid[T]) Row(y int) []T {
+	return g.cells[y*g.Width : (y+1)*g.Width]
+}
+
+// Rows returns a view of every row in order.
+func (g Grid[T]) Rows() [][]T {
+	rows := make([][]T, g.Height)
+	for y := range rows {
+		rows[y] = g.Row(y)
+	}
+	return rows
+}
+
+// Column returns a copy of column x; writes to it don't modify the grid.
+func (g Grid[T]) Column(x int) []T {
+	column := make([]T, g.Height)
+	for y := range column {
+		column[y] = g.Get(Vec2{x, y})
+	}
+	return column
+}
+
+// Transpose returns a copy of the grid mirrored along its main diagonal, so
+// that column x of g is row x of the result.
+func (g Grid[T]) Transpose() Grid[T] {
+	t := New[T](g.Height, g.Width)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			t.Set(Vec2{y, x}, g.Get(Vec2{x, y}))
+		}
+	}
+	return t
+}
+
+// RotateClockwise returns a copy of the grid rotated by 90 degrees clockwise,
+// so that the bottom row of g is the first column of the result.
+func (g Grid[T]) RotateClockwise() Grid[T] {
+	r := New[T](g.Height, g.Width)
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			r.Set(Vec2{g.Height - 1 - y, x}, g.Get(Vec2{x, y}))
+		}
+	}
+	return r
+}
+
+func (g Grid[T]) neighbours(p Vec2, offsets []Vec2) []Vec2 {
+	result := make([]Vec2, 0, len(offsets))
+	for _, offset := range offsets {
+		n := p.Add(offset)
+		if g.InBounds(n) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// Neighbours4 returns the in-bounds positions orthogonally adjacent to p.
+func (g Grid[T]) Neighbours4(p Vec2) []Vec2 {
+	return g.neighbours(p, Neighbourhood4[:])
+}
+
+// Neighbours8 returns the in-bounds positions orthogonally or diagonally adjacent to p.
+func (g Grid[T]) Neighbours8(p Vec2) []Vec2 {
+	return g.neighbours(p, Neighbourhood8[:])
+}
+
+// FloodFill does a breadth-first search from starts, stepping in each direction
+// for which canMove returns true. It returns the number of steps taken to reach
+// each position, or -1 for positions that were never reached.
+func (g Grid[T]) FloodFill(starts []Vec2, canMove func(from Vec2, d Direction) bool) Grid[int] {
+	steps := New[int](g.Width, g.Height)
+	steps.Fill(-1)
+	var expandSet []Vec2
+	for _, start := range starts {
+		if g.InBounds(start) && steps.Get(start) < 0 {
+			steps.Set(start, 0)
+			expandSet = append(expandSet, start)
+		}
+	}
+	for step := 1; len(expandSet) > 0; step++ {
+		var newExpandSet []Vec2
+		for _, p := range expandSet {
+			for _, d := range Directions {
+				n := p.Add(d.Offset())
+				if !g.InBounds(n) || steps.Get(n) >= 0 || !canMove(p, d) {
+					continue
+				}
+				steps.Set(n, step)
+				newExpandSet = append(newExpandSet, n)
+			}
+		}
+		expandSet = newExpandSet
+	}
+	return steps
+}
+
+// Format renders the grid one row per line, using f to render each cell.
+func (g Grid[T]) Format(f func(T) string) string {
+	var sb strings.Builder
+	for y := 0; y < g.Height; y++ {
+		if y != 0 {
+			sb.WriteString("\n")
+		}
+		for _, v := range g.Row(y) {
+			sb.WriteString(f(v))
+		}
+	}
+	return sb.String()
+}
+
+func (g Grid[T]) String() string {
+	return g.Format(func(v T) string {
+		if r, ok := any(v).(rune); ok {
+			return string(r)
+		}
+		return fmt.Sprint(v)
+	})
+}
@@ -0,0 +1,141 @@
+package grid
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"aoc/input"
+)
+
+func parseRunes(t *testing.T, text string) Grid[rune] {
+	t.Helper()
+	lines, err := input.Lines(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := Parse(lines, func(p Vec2, r rune) (rune, error) { return r, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := parseRunes(t, "abc\ndef\n")
+	if g.Size() != (Vec2{3, 2}) || g.Get(Vec2{2, 1}) != 'f' || g.String() != "abc\ndef" {
+		t.Errorf("got %v grid:\n%v", g.Size(), g)
+	}
+
+	tests := []struct {
+		text   string
+		line   int
+		column int
+	}{
+		{"abc\nde\n", 2, 3},
+		{"abc\nabcd\n", 2, 4},
+		{"abc\nabX\n", 2, 3},
+	}
+	for _, test := range tests {
+		lines, err := input.Lines(strings.NewReader(test.text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = Parse(lines, func(p Vec2, r rune) (rune, error) {
+			if r == 'X' {
+				return 0, errors.New("invalid cell")
+			}
+			return r, nil
+		})
+		var parseErr *input.ParseError
+		if !errors.As(err, &parseErr) || parseErr.Line != test.line || parseErr.Column != test.column {
+			t.Errorf("%#v: got %v, expected an error at %v:%v", test.text, err, test.line, test.column)
+		}
+	}
+	if _, err := Parse(nil, func(p Vec2, r rune) (rune, error) { return r, nil }); err == nil {
+		t.Errorf("got no error for an empty grid")
+	}
+}
+
+func TestRowsAndColumns(t *testing.T) {
+	g := parseRunes(t, "abc\ndef\n")
+	g.Row(1)[0] = 'D'
+	if g.Get(Vec2{0, 1}) != 'D' {
+		t.Errorf("writing to a row didn't modify the grid")
+	}
+	column := g.Column(2)
+	column[0] = 'C'
+	if string(column) != "Cf" || g.Get(Vec2{2, 0}) != 'c' {
+		t.Errorf("got column %q and grid:\n%v, expected the column to be a copy", string(column), g)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	g := parseRunes(t, "abc\ndef\n")
+	transposed := g.Transpose()
+	if transposed.String() != "ad\nbe\ncf" {
+		t.Errorf("got:\n%v", transposed)
+	}
+	transposed.Set(Vec2{0, 0}, 'x')
+	if g.Get(Vec2{0, 0}) != 'a' {
+		t.Errorf("modifying the transpose modified the grid")
+	}
+	if back := transposed.Transpose(); back.Get(Vec2{1, 1}) != 'e' || back.Size() != g.Size() {
+		t.Errorf("transposing twice got:\n%v", back)
+	}
+}
+
+func TestRotateClockwise(t *testing.T) {
+	g := parseRunes(t, "abc\ndef\n")
+	rotated := g.RotateClockwise()
+	if rotated.String() != "da\neb\nfc" {
+		t.Errorf("got:\n%v", rotated)
+	}
+	full := g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise()
+	if full.String() != g.String() {
+		t.Errorf("rotating four times got:\n%v", full)
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	g := New[int](3, 2)
+	tests := []struct {
+		p           Vec2
+		neighbours4 []Vec2
+		neighbours8 []Vec2
+	}{
+		{Vec2{0, 0}, []Vec2{{1, 0}, {0, 1}}, []Vec2{{1, 0}, {0, 1}, {1, 1}}},
+		{Vec2{1, 1}, []Vec2{{1, 0}, {2, 1}, {0, 1}}, []Vec2{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {2, 1}}},
+	}
+	for _, test := range tests {
+		if got := g.Neighbours4(test.p); !slices.Equal(got, test.neighbours4) {
+			t.Errorf("Neighbours4(%v): got %v, expected %v", test.p, got, test.neighbours4)
+		}
+		if got := g.Neighbours8(test.p); !slices.Equal(got, test.neighbours8) {
+			t.Errorf("Neighbours8(%v): got %v, expected %v", test.p, got, test.neighbours8)
+		}
+	}
+}
+
+func TestFloodFill(t *testing.T) {
+	g := parseRunes(t, "..#.\n.##.\n....\n")
+	steps := g.FloodFill([]Vec2{{0, 0}}, func(from Vec2, d Direction) bool {
+		return g.Get(from.Add(d.Offset())) != '#'
+	})
+	expected := [][]int{
+		{0, 1, -1, 7},
+		{1, -1, -1, 6},
+		{2, 3, 4, 5},
+	}
+	for y, row := range steps.Rows() {
+		if !slices.Equal(row, expected[y]) {
+			t.Errorf("row %v: got %v, expected %v", y, row, expected[y])
+		}
+	}
+	// Positions out of bounds or walled off are never reached
+	steps = g.FloodFill([]Vec2{{-1, 0}}, func(from Vec2, d Direction) bool { return true })
+	if steps.Get(Vec2{0, 0}) != -1 {
+		t.Errorf("got %v steps from out of bounds, expected -1", steps.Get(Vec2{0, 0}))
+	}
+}
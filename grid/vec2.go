@@ -0,0 +1,96 @@
+package grid
+
+import "fmt"
+
+type Vec2 struct {
+	X int
+	Y int
+}
+
+func (v Vec2) Add(o Vec2) Vec2 {
+	return Vec2{v.X + o.X, v.Y + o.Y}
+}
+
+func (v Vec2) Sub(o Vec2) Vec2 {
+	return Vec2{v.X - o.X, v.Y - o.Y}
+}
+
+// ManhattanDistance returns the number of orthogonal steps needed to get from v to o.
+func (v Vec2) ManhattanDistance(o Vec2) int {
+	return abs(v.X-o.X) + abs(v.Y-o.Y)
+}
+
+func (v Vec2) String() string {
+	return fmt.Sprintf("(%v,%v)", v.X, v.Y)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+type Direction byte
+
+const (
+	North Direction = iota
+	East
+	South
+	West
+)
+
+var Directions [4]Direction = [4]Direction{North, East, South, West}
+
+func (d Direction) Offset() Vec2 {
+	switch d {
+	case North:
+		return Vec2{Y: -1}
+	case East:
+		return Vec2{X: 1}
+	case South:
+		return Vec2{Y: 1}
+	case West:
+		return Vec2{X: -1}
+	}
+	panic("invalid direction")
+}
+
+func (d Direction) Invert() Direction {
+	switch d {
+	case North:
+		return South
+	case East:
+		return West
+	case South:
+		return North
+	case West:
+		return East
+	}
+	panic("invalid direction")
+}
+
+func (d Direction) String() string {
+	switch d {
+	case North:
+		return "N"
+	case East:
+		return "E"
+	case South:
+		return "S"
+	case West:
+		return "W"
+	}
+	return fmt.Sprintf("?%v?", byte(d))
+}
+
+// Neighbourhood4 holds the offsets of the orthogonally adjacent positions.
+var Neighbourhood4 [4]Vec2 = [4]Vec2{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// Neighbourhood8 holds the offsets of the orthogonally and diagonally adjacent positions,
+// in reading order.
+var Neighbourhood8 [8]Vec2 = [8]Vec2{
+	{-1, -1}, {0, -1}, {1, -1},
+	{-1, 0}, {1, 0},
+	{-1, 1}, {0, 1}, {1, 1},
+}
@@ -0,0 +1,90 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError is returned by parsers for invalid input, locating the problem in the input file.
+type ParseError struct {
+	// File is the input path, or empty if unknown
+	File string
+	// Line and Column are 1-based, or 0 if unknown. Column counts bytes.
+	Line   int
+	Column int
+	// Text is the whole line of input containing the problem
+	Text string
+	Err  error
+}
+
+// Position returns the location of the error as "file:line:column", leaving out unknown parts.
+func (e *ParseError) Position() string {
+	var parts []string
+	if e.File != "" {
+		parts = append(parts, e.File)
+	}
+	if e.Line > 0 {
+		parts = append(parts, strconv.Itoa(e.Line))
+		if e.Column > 0 {
+			parts = append(parts, strconv.Itoa(e.Column))
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// Snippet renders the line of input containing the error, with a caret under the column, e.g.
+//
+//	3 | Game 3: 8 green, x blue
+//	  |                  ^
+func (e *ParseError) Snippet() string {
+	if e.Text == "" {
+		return ""
+	}
+	gutter := ""
+	if e.Line > 0 {
+		gutter = strconv.Itoa(e.Line)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s | %s", gutter, e.Text)
+	if e.Column > 0 {
+		// keep tabs so that the caret lines up however wide they are displayed
+		prefix := e.Text[:min(e.Column-1, len(e.Text))]
+		indent := strings.Map(func(r rune) rune {
+			if r == '\t' {
+				return r
+			}
+			return ' '
+		}, prefix)
+		fmt.Fprintf(&sb, "\n%s | %s^", strings.Repeat(" ", len(gutter)), indent)
+	}
+	return sb.String()
+}
+
+func (e *ParseError) Error() string {
+	msg := e.Err.Error()
+	if position := e.Position(); position != "" {
+		msg = position + ": " + msg
+	}
+	if snippet := e.Snippet(); snippet != "" {
+		msg += "\n" + snippet
+	}
+	return msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// SetFile records file as the input path of the *ParseError in err's chain, if
+// there is one without a path already.
+func SetFile(err error, file string) {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && parseErr.File == "" {
+		if file == Stdin {
+			file = "<stdin>"
+		}
+		parseErr.File = file
+	}
+}
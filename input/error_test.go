@@ -0,0 +1,35 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorLocation(t *testing.T) {
+	lines, err := Lines(strings.NewReader("Time: 7 15\n\n  Distance: 9 x 200\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || lines[1].Number != 3 {
+		t.Fatalf("got lines %+v, expected line 3 to follow line 1", lines)
+	}
+	_, err = lines[1].IntsPrefix("Distance:")
+	err = fmt.Errorf("wrapped: %w", err)
+	SetFile(err, "input.txt")
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("got %v, expected a *ParseError", err)
+	}
+	if parseErr.File != "input.txt" || parseErr.Line != 3 || parseErr.Column != 15 {
+		t.Errorf("got position %v, expected input.txt:3:15", parseErr.Position())
+	}
+	expected := "input.txt:3:15: invalid number \"x\"\n" +
+		"3 |   Distance: 9 x 200\n" +
+		"  |               ^"
+	if parseErr.Error() != expected {
+		t.Errorf("got:\n%v\nexpected:\n%v", parseErr.Error(), expected)
+	}
+}
@@ -0,0 +1,3 @@
+module aoc/input
+
+go 1.21.1
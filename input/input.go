@@ -0,0 +1,58 @@
+// Package input contains the readers shared by every day for parsing puzzle input.
+package input
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Lines returns every non-blank line of r, with surrounding whitespace trimmed.
+func Lines(r io.Reader) ([]Line, error) {
+	var lines []Line
+	err := scan(r, func(line Line) {
+		if line.Text != "" {
+			lines = append(lines, line)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// Paragraphs returns the groups of lines of r that are separated by one or
+// more blank lines, with surrounding whitespace trimmed.
+func Paragraphs(r io.Reader) ([][]Line, error) {
+	var paragraphs [][]Line
+	var paragraph []Line
+	err := scan(r, func(line Line) {
+		if line.Text == "" {
+			if paragraph != nil {
+				paragraphs = append(paragraphs, paragraph)
+				paragraph = nil
+			}
+			return
+		}
+		paragraph = append(paragraph, line)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if paragraph != nil {
+		paragraphs = append(paragraphs, paragraph)
+	}
+	return paragraphs, nil
+}
+
+// scan calls f with every line of r, numbered from 1.
+func scan(r io.Reader, f func(line Line)) error {
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		raw := scanner.Text()
+		text := strings.TrimLeftFunc(raw, isSpace)
+		offset := len(raw) - len(text)
+		f(Line{Number: number, Text: strings.TrimRightFunc(text, isSpace), raw: raw, offset: offset})
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,148 @@
+package input
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Line is a piece of a line of input. It remembers where it came from, so that
+// errors created from it can point back at the offending text.
+type Line struct {
+	// Number is the 1-based line number in the input, or 0 if unknown
+	Number int
+	Text   string
+	// raw is the whole line Text was cut from, and offset is where Text starts in it
+	raw    string
+	offset int
+}
+
+func isSpace(r rune) bool {
+	return unicode.IsSpace(r)
+}
+
+func (l Line) String() string {
+	return l.Text
+}
+
+// Slice returns the part of the line between byte offsets i and j of its text.
+func (l Line) Slice(i int, j int) Line {
+	if l.raw == "" {
+		l.raw = l.Text
+	}
+	l.Text = l.Text[i:j]
+	l.offset += i
+	return l
+}
+
+// From returns the part of the line from byte offset i of its text onwards.
+func (l Line) From(i int) Line {
+	return l.Slice(i, len(l.Text))
+}
+
+// TrimSpace returns the line without leading and trailing whitespace.
+func (l Line) TrimSpace() Line {
+	start := len(l.Text) - len(strings.TrimLeftFunc(l.Text, isSpace))
+	end := len(strings.TrimRightFunc(l.Text, isSpace))
+	if end < start {
+		end = start
+	}
+	return l.Slice(start, end)
+}
+
+// Cut slices the line around the first instance of sep, like strings.Cut.
+func (l Line) Cut(sep string) (before Line, after Line, found bool) {
+	i := strings.Index(l.Text, sep)
+	if i < 0 {
+		return l, l.From(len(l.Text)), false
+	}
+	return l.Slice(0, i), l.From(i + len(sep)), true
+}
+
+// CutPrefix returns the line without the given prefix, or an error if it doesn't start with it.
+func (l Line) CutPrefix(prefix string) (Line, error) {
+	if !strings.HasPrefix(l.Text, prefix) {
+		return Line{}, l.Errorf("expected %#v", prefix)
+	}
+	return l.From(len(prefix)), nil
+}
+
+// Split slices the line into the parts separated by sep, like strings.Split.
+func (l Line) Split(sep string) []Line {
+	var parts []Line
+	for {
+		before, after, found := l.Cut(sep)
+		parts = append(parts, before)
+		if !found {
+			return parts
+		}
+		l = after
+	}
+}
+
+// Fields splits the line around runs of whitespace, like strings.Fields.
+func (l Line) Fields() []Line {
+	var fields []Line
+	start := -1
+	for i, r := range l.Text {
+		if isSpace(r) {
+			if start >= 0 {
+				fields = append(fields, l.Slice(start, i))
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, l.From(start))
+	}
+	return fields
+}
+
+// Atoi parses the line as a decimal integer.
+func (l Line) Atoi() (int, error) {
+	number, err := strconv.Atoi(l.Text)
+	if err != nil {
+		return 0, l.Errorf("invalid number %#v", l.Text)
+	}
+	return number, nil
+}
+
+// Ints parses the whitespace-separated integers in the line.
+func (l Line) Ints() ([]int, error) {
+	var numbers []int
+	for _, field := range l.Fields() {
+		number, err := field.Atoi()
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// IntsPrefix parses the whitespace-separated integers in the line that follow
+// the given prefix, e.g. "Time:      7  15   30".
+func (l Line) IntsPrefix(prefix string) ([]int, error) {
+	rest, err := l.CutPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	return rest.Ints()
+}
+
+// Error returns a *ParseError for err pointing at the start of the line.
+func (l Line) Error(err error) error {
+	raw := l.raw
+	if raw == "" {
+		raw = l.Text
+	}
+	return &ParseError{Line: l.Number, Column: l.offset + 1, Text: raw, Err: err}
+}
+
+// Errorf is like Error, formatting the error as fmt.Errorf does.
+func (l Line) Errorf(format string, a ...any) error {
+	return l.Error(fmt.Errorf(format, a...))
+}
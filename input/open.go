@@ -0,0 +1,56 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+)
+
+// Stdin is the path that Open treats as standard input.
+const Stdin = "-"
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Open opens the puzzle input at path, or standard input if path is "-".
+// Gzip-compressed input is decompressed transparently.
+func Open(path string) (io.ReadCloser, error) {
+	if path == Stdin {
+		r, err := Decompress(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(r), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := Decompress(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return readCloser{r, file}, nil
+}
+
+// Decompress returns a reader of the decompressed contents of r if it is
+// gzip-compressed, or of r unchanged otherwise.
+func Decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if !bytes.Equal(magic, gzipMagic) {
+		return br, nil
+	}
+	return gzip.NewReader(br)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,68 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// AnswersFileName is the name of the file in each day's directory that holds
+// the expected answers for that day's input files.
+const AnswersFileName = "answers.json"
+
+type ExpectedAnswer struct {
+	Input  string   `json:"input"`
+	Part   int      `json:"part"`
+	Args   []string `json:"args,omitempty"`
+	Answer int      `json:"answer"`
+}
+
+// LoadAnswers reads the expected answers from dir, returning none if dir has no answers file.
+func LoadAnswers(dir string) ([]ExpectedAnswer, error) {
+	data, err := os.ReadFile(filepath.Join(dir, AnswersFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var answers []ExpectedAnswer
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// SaveAnswers writes answers to dir, sorted by input file and part.
+func SaveAnswers(dir string, answers []ExpectedAnswer) error {
+	slices.SortStableFunc(answers, func(a, b ExpectedAnswer) int {
+		if a.Input != b.Input {
+			if a.Input < b.Input {
+				return -1
+			}
+			return 1
+		}
+		return a.Part - b.Part
+	})
+	if answers == nil {
+		answers = []ExpectedAnswer{}
+	}
+	data, err := json.MarshalIndent(answers, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, AnswersFileName), append(data, '\n'), 0o644)
+}
+
+// FindAnswer returns the expected answer for the given input file, part and
+// extra arguments, if known. No arguments matches answers recorded without any.
+func FindAnswer(answers []ExpectedAnswer, input string, part int, args []string) (ExpectedAnswer, bool) {
+	for _, answer := range answers {
+		if answer.Input == input && answer.Part == part && slices.Equal(answer.Args, args) {
+			return answer, true
+		}
+	}
+	return ExpectedAnswer{}, false
+}
@@ -0,0 +1,31 @@
+package solver
+
+import "testing"
+
+func TestFindAnswer(t *testing.T) {
+	answers := []ExpectedAnswer{
+		{Input: "input_simple.txt", Part: 2, Answer: 82000210},
+		{Input: "input_simple.txt", Part: 2, Args: []string{"10"}, Answer: 1030},
+		{Input: "input_simple.txt", Part: 2, Args: []string{"100"}, Answer: 8410},
+	}
+	tests := []struct {
+		args     []string
+		expected int
+		found    bool
+	}{
+		{nil, 82000210, true},
+		{[]string{}, 82000210, true},
+		{[]string{"10"}, 1030, true},
+		{[]string{"100"}, 8410, true},
+		{[]string{"1000"}, 0, false},
+	}
+	for _, test := range tests {
+		answer, found := FindAnswer(answers, "input_simple.txt", 2, test.args)
+		if found != test.found || answer.Answer != test.expected {
+			t.Errorf("%#v: got %v, %v, expected %v, %v", test.args, answer.Answer, found, test.expected, test.found)
+		}
+	}
+	if _, found := FindAnswer(answers, "input_simple.txt", 1, nil); found {
+		t.Errorf("found an answer for part 1")
+	}
+}
@@ -0,0 +1,3 @@
+module aoc/solver
+
+go 1.21.1
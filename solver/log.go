@@ -0,0 +1,33 @@
+package solver
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// DebugEnabled reports whether logger outputs debug records, so that traces
+// which are expensive to build can be skipped.
+func DebugEnabled(logger *slog.Logger) bool {
+	return logger.Enabled(context.Background(), slog.LevelDebug)
+}
+
+// DebugLines logs a multi-line rendering, such as a grid, one debug record
+// per line. render is only called if debug logging is enabled.
+func DebugLines(logger *slog.Logger, msg string, render func() string) {
+	if !DebugEnabled(logger) {
+		return
+	}
+	for i, line := range strings.Split(strings.TrimSuffix(render(), "\n"), "\n") {
+		logger.Debug(msg, "line", i+1, "text", line)
+	}
+}
@@ -0,0 +1,187 @@
+package solver
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+
+	"aoc/input"
+)
+
+type Day struct {
+	Number int
+	// Args names the optional extra arguments accepted after the input path, in order
+	Args []string
+	New  func(opts Options) (Solver, error)
+	// Generate returns a random, structurally valid puzzle input, if the day has
+	// a generator. size scales the puzzle, with 0 or less giving the day's default.
+	Generate func(rng *rand.Rand, size int) string
+	// MaxSize is the largest size Generate accepts, or 0 if any size is accepted
+	MaxSize int
+	// Reference optionally creates a solver that gets the same answers as New's
+	// by other, usually brute-force, means, for differential testing. It
+	// returns an error wrapping ErrTooBig for inputs too big to check that way.
+	Reference func(opts Options) (Solver, error)
+	// ReferenceArgs optionally lists the extra arguments to compare New and
+	// Reference with when none are given, e.g. where the defaults make every
+	// input too big for Reference
+	ReferenceArgs [][]string
+}
+
+var days = make(map[int]Day)
+
+// Register adds a day to the registry. It is intended to be called from the
+// init function of each day's package, and panics if the day is registered twice.
+func Register(day Day) {
+	if day.New == nil {
+		panic(fmt.Sprintf("day %v registered with nil New", day.Number))
+	}
+	if _, found := days[day.Number]; found {
+		panic(fmt.Sprintf("day %v registered twice", day.Number))
+	}
+	days[day.Number] = day
+}
+
+func Lookup(number int) (Day, bool) {
+	day, found := days[number]
+	return day, found
+}
+
+// Days returns every registered day, sorted by day number.
+func Days() []Day {
+	result := make([]Day, 0, len(days))
+	for _, day := range days {
+		result = append(result, day)
+	}
+	slices.SortFunc(result, func(a, b Day) int { return a.Number - b.Number })
+	return result
+}
+
+func ParsePart(s string) (int, error) {
+	switch s {
+	case "1":
+		return 1, nil
+	case "2":
+		return 2, nil
+	}
+	return 0, fmt.Errorf("invalid part number %#v. Expected 1/2", s)
+}
+
+func ParseDay(s string) (int, error) {
+	number, err := strconv.Atoi(s)
+	if err != nil || number < 1 || number > 25 {
+		return 0, fmt.Errorf("invalid day %#v. Expected 1-25", s)
+	}
+	return number, nil
+}
+
+// Usage describes the extra arguments accepted after the input path, e.g. "[useWords]".
+func (d Day) Usage() string {
+	usage := make([]string, len(d.Args))
+	for i, arg := range d.Args {
+		usage[i] = "[" + arg + "]"
+	}
+	return strings.Join(usage, " ")
+}
+
+// ResolveArgs returns the extra arguments in order, given positionally or by
+// name as --name=value, e.g. "--vocabulary=de". Arguments left out before the
+// last one given are empty, which days treat as their default.
+func (d Day) ResolveArgs(args []string) ([]string, error) {
+	var resolved []string
+	given := make([]bool, len(d.Args))
+	set := func(i int, value string) {
+		for len(resolved) <= i {
+			resolved = append(resolved, "")
+		}
+		resolved[i] = value
+		given[i] = true
+	}
+	positional := 0
+	for _, arg := range args {
+		named, found := strings.CutPrefix(arg, "--")
+		if !found {
+			if positional >= len(d.Args) {
+				return nil, &ArgsError{Day: d.Number, Err: fmt.Errorf("too many arguments after <inputPath>, expected %v, got %#v", d.Usage(), args)}
+			}
+			if given[positional] {
+				return nil, &ArgsError{Day: d.Number, Err: fmt.Errorf("argument %v given twice", d.Args[positional])}
+			}
+			set(positional, arg)
+			positional++
+			continue
+		}
+		name, value, found := strings.Cut(named, "=")
+		if !found {
+			return nil, &ArgsError{Day: d.Number, Err: fmt.Errorf("invalid argument %#v. Expected --name=value", arg)}
+		}
+		i := slices.Index(d.Args, name)
+		if i < 0 && len(d.Args) == 0 {
+			return nil, &ArgsError{Day: d.Number, Err: fmt.Errorf("no arguments expected after <inputPath>, got %#v", args)}
+		} else if i < 0 {
+			return nil, &ArgsError{Day: d.Number, Err: fmt.Errorf("unknown argument %#v. Expected %v", name, strings.Join(d.Args, "/"))}
+		}
+		if given[i] {
+			return nil, &ArgsError{Day: d.Number, Err: fmt.Errorf("argument %v given twice", name)}
+		}
+		set(i, value)
+	}
+	return resolved, nil
+}
+
+// NewSolver creates a solver for the day, rejecting more extra arguments than the day accepts.
+func (d Day) NewSolver(opts Options) (Solver, error) {
+	if len(opts.Args) > len(d.Args) {
+		if len(d.Args) == 0 {
+			return nil, &ArgsError{Day: d.Number, Err: fmt.Errorf("no arguments expected after <inputPath>, got %#v", opts.Args)}
+		}
+		return nil, &ArgsError{Day: d.Number, Err: fmt.Errorf("too many arguments after <inputPath>, expected %v, got %#v", d.Usage(), opts.Args)}
+	}
+	s, err := d.New(opts)
+	if err != nil {
+		return nil, &ArgsError{Day: d.Number, Err: err}
+	}
+	return s, nil
+}
+
+// ArgsError is returned when a day's extra arguments are invalid.
+type ArgsError struct {
+	Day int
+	Err error
+}
+
+func (e *ArgsError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ArgsError) Unwrap() error {
+	return e.Err
+}
+
+// Solve parses the input at inputPath with a new solver for the day and solves
+// the given part. See input.Open for the paths accepted.
+func (d Day) Solve(part int, inputPath string, opts Options) (Answer, error) {
+	r, err := input.Open(inputPath)
+	if err != nil {
+		return Answer{}, err
+	}
+	defer r.Close()
+	answer, err := d.SolveReader(part, r, opts)
+	input.SetFile(err, inputPath)
+	return answer, err
+}
+
+// SolveReader parses the input from r with a new solver for the day and solves the given part.
+func (d Day) SolveReader(part int, r io.Reader, opts Options) (Answer, error) {
+	s, err := d.NewSolver(opts)
+	if err != nil {
+		return Answer{}, err
+	}
+	if err := s.Parse(r); err != nil {
+		return Answer{}, err
+	}
+	return SolvePart(s, part)
+}
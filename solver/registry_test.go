@@ -0,0 +1,47 @@
+package solver
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveArgs(t *testing.T) {
+	day := Day{Number: 1, Args: []string{"useWords", "vocabulary", "explain"}}
+	valid := []struct {
+		args     []string
+		expected []string
+	}{
+		{nil, nil},
+		{[]string{"true", "de"}, []string{"true", "de"}},
+		{[]string{"--vocabulary=de"}, []string{"", "de"}},
+		{[]string{"--explain=all", "--useWords=true"}, []string{"true", "", "all"}},
+		{[]string{"true", "--explain=disagree"}, []string{"true", "", "disagree"}},
+		{[]string{"--vocabulary=x.toml#a=b"}, []string{"", "x.toml#a=b"}},
+	}
+	for _, test := range valid {
+		got, err := day.ResolveArgs(test.args)
+		if err != nil {
+			t.Errorf("%#v: %v", test.args, err)
+		} else if !slices.Equal(got, test.expected) {
+			t.Errorf("%#v: got %#v, expected %#v", test.args, got, test.expected)
+		}
+	}
+
+	invalid := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"true", "de", "all", "x"}, "too many arguments"},
+		{[]string{"--bag=1"}, "unknown argument \"bag\""},
+		{[]string{"--vocabulary"}, "expected --name=value"},
+		{[]string{"--vocabulary=de", "--vocabulary=fr"}, "vocabulary given twice"},
+		{[]string{"--useWords=true", "false"}, "useWords given twice"},
+	}
+	for _, test := range invalid {
+		_, err := day.ResolveArgs(test.args)
+		if err == nil || !strings.Contains(strings.ToLower(err.Error()), strings.ToLower(test.err)) {
+			t.Errorf("%#v: got error %v, expected %#v", test.args, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package solver defines the interface implemented by every day's solution,
+// and the registry through which the aoc command finds them.
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+)
+
+// ErrNotImplemented is returned by days whose solution has not been written yet.
+var ErrNotImplemented = errors.New("not implemented")
+
+// ErrTooBig is returned by reference solvers for inputs too big to solve their way.
+var ErrTooBig = errors.New("too big for the reference solver")
+
+// ErrNoFiles is returned by Options.ReadArgFile where arguments can't name files.
+var ErrNoFiles = errors.New("arguments can't name files here")
+
+// Answer is the solution to one part of a puzzle.
+type Answer struct {
+	Value int
+	// Details optionally holds intermediate results worth reporting alongside
+	// the value. It is included in JSON output, so should marshal cleanly.
+	Details any
+}
+
+func (a Answer) String() string {
+	return strconv.Itoa(a.Value)
+}
+
+// Solver solves both parts of one day's puzzle. Parse is called once with the
+// puzzle input before either part is solved.
+type Solver interface {
+	Parse(r io.Reader) error
+	Part1() (Answer, error)
+	Part2() (Answer, error)
+}
+
+type Options struct {
+	// Args holds any extra day-specific arguments given after the input path
+	Args []string
+	// Log receives diagnostic output, if set
+	Log *slog.Logger
+	// ReadFile reads files named by Args. It is nil where solvers mustn't read
+	// files, e.g. when serving requests over HTTP.
+	ReadFile func(name string) ([]byte, error)
+}
+
+// Logger returns the logger for diagnostics, discarding them if none was set.
+func (o Options) Logger() *slog.Logger {
+	if o.Log == nil {
+		return discardLogger
+	}
+	return o.Log
+}
+
+// ReadArgFile reads a file named by an argument, failing with ErrNoFiles if
+// files can't be read.
+func (o Options) ReadArgFile(name string) ([]byte, error) {
+	if o.ReadFile == nil {
+		return nil, fmt.Errorf("reading %v: %w", name, ErrNoFiles)
+	}
+	return o.ReadFile(name)
+}
+
+// SolvePart solves the given part using a solver that has already parsed its input.
+func SolvePart(s Solver, part int) (Answer, error) {
+	switch part {
+	case 1:
+		return s.Part1()
+	case 2:
+		return s.Part2()
+	}
+	return Answer{}, fmt.Errorf("unknown part %v", part)
+}